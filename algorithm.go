@@ -0,0 +1,33 @@
+package seimei
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+// ParseAlgorithms selects the registered algorithms by the names in the given order, such as the flag --algorithm.
+func ParseAlgorithms(names []string) ([]parser.Algorithm, error) {
+	as := make([]parser.Algorithm, 0, len(names))
+
+	for _, name := range names {
+		a := parser.Algorithm(name)
+		if _, ok := parser.LookupParser(a); !ok {
+			return nil, fmt.Errorf("%w: %q (registered: %s)", parser.ErrUnknownAlgorithm, name, joinAlgorithms(parser.Algorithms()))
+		}
+
+		as = append(as, a)
+	}
+
+	return as, nil
+}
+
+func joinAlgorithms(as []parser.Algorithm) string {
+	s := make([]string, len(as))
+	for i, a := range as {
+		s[i] = string(a)
+	}
+
+	return strings.Join(s, ", ")
+}
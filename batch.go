@@ -0,0 +1,173 @@
+package seimei
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+// row is a record read by ParseReader.
+type row struct {
+	line   int
+	record []string
+	input  string
+	// reading is the kana reading of input when ParseReader divides pairs.
+	reading string
+	// header is true for the header record, and index is the name column resolved by it.
+	header bool
+	index  int
+	// skip is written to stderr instead of dividing the record.
+	skip string
+	// fatal stops ParseReader.
+	fatal error
+}
+
+// readRows sends the records of r until EOF, a fatal error or ctx is done.
+// readingIndex is the reading column, which is used only when cfg has it.
+func readRows(ctx context.Context, r *csv.Reader, cfg config, index, readingIndex int) <-chan row {
+	rows := make(chan row)
+
+	go func() {
+		defer close(rows)
+
+		send := func(v row) bool {
+			select {
+			case rows <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var header []string
+
+		for c := 1; ; c++ {
+			record, err := r.Read()
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			//nolint:exhaustivestruct
+			v := row{line: c, record: record, index: index}
+
+			switch {
+			case err != nil:
+				v.skip = fmt.Sprintf("load line error on line %d: %v", c, err)
+			case cfg.header && header == nil:
+				header = record
+				v.header = true
+
+				if cfg.column != "" {
+					index, err = resolveColumn(cfg.column, header)
+					if err != nil {
+						v.fatal = fmt.Errorf("happen error select column: %w", err)
+					}
+
+					v.index = index
+				}
+
+				if cfg.reading != "" && v.fatal == nil {
+					readingIndex, err = resolveColumn(cfg.reading, header)
+					if err != nil {
+						v.fatal = fmt.Errorf("happen error select reading column: %w", err)
+					}
+				}
+
+				// The header is checked as well, since the names are inserted after its column.
+				if v.fatal == nil && cfg.column != "" && index >= len(header) {
+					v.fatal = fmt.Errorf("happen error select column: %w: %d", ErrColumnOutOfRecord, index)
+				}
+
+				if v.fatal == nil && cfg.reading != "" && readingIndex >= len(header) {
+					v.fatal = fmt.Errorf("happen error select reading column: %w: %d", ErrColumnOutOfRecord, readingIndex)
+				}
+			case cfg.column == "" && len(record) != 1:
+				v.skip = fmt.Sprintf("format error on line %d: %v", c, record)
+			case index >= len(record) || (cfg.reading != "" && readingIndex >= len(record)):
+				v.skip = fmt.Sprintf("format error on line %d: %v: %v", c, ErrColumnOutOfRecord, record)
+			default:
+				v.input = record[index]
+
+				if cfg.reading != "" {
+					v.reading = record[readingIndex]
+				}
+			}
+
+			if !send(v) || v.fatal != nil {
+				return
+			}
+		}
+	}()
+
+	return rows
+}
+
+// divided is a row with the result of dividing its input.
+type divided struct {
+	row     row
+	name    parser.DividedName
+	reading parser.DividedName
+	// romanized is given when the romanizer is.
+	romanized string
+	err       error
+}
+
+// nameDivider is parser.NameParser or parser.CachedNameParser.
+type nameDivider interface {
+	Parse(fullname parser.FullName) (parser.DividedName, error)
+}
+
+func divideRow(p nameDivider) func(row) divided {
+	return func(r row) divided {
+		//nolint:exhaustivestruct
+		if r.header || r.skip != "" || r.fatal != nil {
+			return divided{row: r}
+		}
+
+		name, err := p.Parse(parser.FullName(r.input))
+
+		return divided{
+			row:  r,
+			name: name,
+			err:  err,
+		}
+	}
+}
+
+// dividePairRow divides the input of each row together with its reading.
+func dividePairRow(p parser.NameParser) func(row) divided {
+	return func(r row) divided {
+		//nolint:exhaustivestruct
+		if r.header || r.skip != "" || r.fatal != nil {
+			return divided{row: r}
+		}
+
+		v, err := p.ParsePair(parser.FullName(r.input), parser.FullName(r.reading))
+
+		return divided{
+			row:     r,
+			name:    v.Name,
+			reading: v.Reading,
+			err:     err,
+		}
+	}
+}
+
+// romanizeRow adds the romanisation to the result of f.
+func romanizeRow(cfg config, f func(row) divided) func(row) divided {
+	return func(r row) divided {
+		d := f(r)
+		if d.err != nil || d.name.IsZero() {
+			return d
+		}
+
+		d.romanized, d.err = cfg.romanize(d.name, d.reading)
+
+		return d
+	}
+}
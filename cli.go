@@ -3,10 +3,16 @@ package seimei
 import (
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	// Using embed.
 	_ "embed"
 
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/glassmonkey/seimei/v2/romanize"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +24,27 @@ var (
 	ErrEmptyPath          = errors.New("provide path is empty (ex. /tmp/foo.csv)")
 	ErrInvalidPath        = errors.New("provide path is invalid")
 	ErrInvalidParseString = errors.New("provide parse string is invalid")
+	ErrInvalidTop         = errors.New("provide top is invalid (ex. 3)")
+	ErrInvalidMinScore    = errors.New("provide min score is invalid (ex. 0.5)")
+	ErrInvalidMinMargin   = errors.New("provide min margin is invalid (ex. 0.1)")
+	ErrInvalidRejectPath  = errors.New("provide reject path is invalid")
+	ErrInvalidFeaturePath = errors.New("provide features path is invalid")
+	ErrInvalidOutput      = errors.New("provide output is invalid (ex. json)")
+	ErrInvalidColumnFlag  = errors.New("provide column is invalid (ex. 1 or name)")
+	ErrDuplicatePath      = errors.New("provide path either as the flag or as the argument")
+	ErrInvalidWorkers     = errors.New("provide workers is invalid (ex. 4)")
+	ErrInvalidCacheSize   = errors.New("provide cache size is invalid (ex. 10000)")
+	ErrInvalidAddr        = errors.New("provide addr is invalid (ex. :8080)")
+	ErrInvalidLimit       = errors.New("provide limit is invalid (ex. 64)")
+	ErrInvalidNormalize   = errors.New("provide normalize is invalid (ex. nfkc,space)")
+	ErrInvalidGiven       = errors.New("provide given is invalid (ex. --given --delimiters ' ,')")
+	ErrGivenNormalize     = errors.New("provide normalize keeping the delimiters of given (ex. --normalize nfkc)")
+	ErrInvalidDictPath    = errors.New("provide dict path is invalid")
+	ErrInvalidAlgorithm   = errors.New("provide algorithm is invalid (ex. rule,statistics)")
+	ErrInvalidKanaPath    = errors.New("provide kana features path is invalid")
+	ErrInvalidLatinPath   = errors.New("provide latin features path is invalid")
+	ErrInvalidTrain       = errors.New("provide either kana or latin to train (ex. --kana)")
+	ErrInvalidRomanize    = errors.New("provide romanize is invalid (ex. --long-vowel macron --name-order given-family)")
 )
 
 type CmdMode string
@@ -27,9 +54,39 @@ func (c CmdMode) String() string {
 }
 
 const (
-	NameCmd     CmdMode = "name"
-	FileCmd     CmdMode = "file"
-	ParseOption string  = "parse"
+	NameCmd         CmdMode = "name"
+	FileCmd         CmdMode = "file"
+	CandidatesCmd   CmdMode = "candidates"
+	ParseOption     string  = "parse"
+	TopOption       string  = "top"
+	MinScoreOption  string  = "min-score"
+	MinMarginOption string  = "min-margin"
+	RejectOption    string  = "reject"
+	FeaturesOption  string  = "features"
+	OutputOption    string  = "output"
+	ColumnOption    string  = "column"
+	ReadingOption   string  = "reading-column"
+	HeaderOption    string  = "header"
+	InsertOption    string  = "insert"
+	WorkersOption   string  = "workers"
+	CacheSizeOption string  = "cache-size"
+	AddrOption      string  = "addr"
+	MaxNameOption   string  = "max-name-length"
+	MaxBatchOption  string  = "max-batch-size"
+	NormalizeOption string  = "normalize"
+	VariantsOption  string  = "fold-variants"
+	GivenOption     string  = "given"
+	DelimiterOption string  = "delimiters"
+	CheckOption     string  = "check-given"
+	DictOption      string  = "dict"
+	AlgorithmOption string  = "algorithm"
+	KanaOption      string  = "kana"
+	KanaTableOption string  = "kana-features"
+	LatinOption     string  = "latin"
+	LatinFeatOption string  = "latin-features"
+	RomanizeOption  string  = "romanize"
+	LongVowelOption string  = "long-vowel"
+	NameOrderOption string  = "name-order"
 )
 
 func BuildMainCmd() *cobra.Command {
@@ -46,6 +103,13 @@ func BuildMainCmd() *cobra.Command {
 	cobra.EnableCommandSorting = false
 	c.AddCommand(BuildNameCmd())
 	c.AddCommand(BuildFileCmd())
+	c.AddCommand(BuildCandidatesCmd())
+	c.AddCommand(BuildExplainCmd())
+	c.AddCommand(BuildTrainCmd())
+	c.AddCommand(BuildEvalCmd())
+	c.AddCommand(BuildServeCmd())
+	c.AddCommand(BuildGRPCServeCmd())
+	c.AddCommand(BuildDictCmd())
 	return &c
 }
 
@@ -66,7 +130,21 @@ Provide the full name to be parsed with the required flag (--name).
 			if err != nil {
 				return fmt.Errorf("flag parse error: %w", err)
 			}
-			return ParseName(cmd.OutOrStdout(), cmd.OutOrStderr(), n, p)
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			ro, err := detectFlagRomanize(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, ro...)
+			o, err := detectFlagOutput(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, WithOutputFormat(o))
+			return ParseName(cmd.OutOrStdout(), cmd.ErrOrStderr(), n, p, opts...)
 		},
 	}
 	c.Flags().SortFlags = false
@@ -77,17 +155,195 @@ Provide the full name to be parsed with the required flag (--name).
 		panic(err)
 	}
 	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	c.Flags().Bool(RomanizeOption, false, "write kana names in modified Hepburn such as YAMADA Hanako")
+	c.Flags().String(LongVowelOption, string(romanize.Omit), "long vowels with --romanize: omit, macron or keep")
+	c.Flags().String(NameOrderOption, string(romanize.FamilyGiven), "order with --romanize: family-given or given-family")
+	c.Flags().StringP(OutputOption, "o", string(TextFormat), "text, json, jsonl, csv or tsv")
 	return &c
 }
 
 func BuildFileCmd() *cobra.Command {
 	c := cobra.Command{
-		Use:   "file",
+		Use:   "file [path]",
 		Short: "It bulk parse full name lit in the file.",
 		Long: `It bulk parse full name lit in the file.
-Provide the file path with full name list to the required flag (--file).
+Provide the file path with full name list to the flag (--file) or as the argument.
+When the path is "-" or not provided, the full names are read from stdin.
+`,
+		Example: `seimei file --file /path/to/dir/foo.csv
+cut -f2 users.tsv | seimei file -
+seimei file --file users.csv --header --column name --reading-column kana`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := detectFlagForInput(cmd, args)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			ro, err := detectFlagRomanize(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, ro...)
+			o, err := detectFlagOutput(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, WithOutputFormat(o))
+			co, err := detectFlagColumn(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, co...)
+			n, err := detectFlagWorkers(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, WithWorkers(n))
+			cs, err := detectFlagCacheSize(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, WithCacheSize(cs))
+			r, err := detectFlagReject(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			if r != "" {
+				w, err := os.Create(string(r))
+				if err != nil {
+					return fmt.Errorf("happen error create reject file: %w", err)
+				}
+				defer w.Close()
+				opts = append(opts, WithRejectWriter(w))
+			}
+			if f == Stdin {
+				return ParseReader(cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin(), p, opts...)
+			}
+			return ParseFile(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p, opts...)
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().StringP(FileCmd.String(), "f", "", "/path/to/dir/foo.csv (default stdin)")
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	c.Flags().String(RejectOption, "", "/path/to/dir/reject.csv")
+	c.Flags().Bool(RomanizeOption, false, "write kana names in modified Hepburn such as YAMADA Hanako")
+	c.Flags().String(LongVowelOption, string(romanize.Omit), "long vowels with --romanize: omit, macron or keep")
+	c.Flags().String(NameOrderOption, string(romanize.FamilyGiven), "order with --romanize: family-given or given-family")
+	c.Flags().StringP(OutputOption, "o", string(TextFormat), "text, json, jsonl, csv or tsv")
+	c.Flags().StringP(ColumnOption, "c", "", "zero-based index or header name of the name column")
+	c.Flags().String(ReadingOption, "", "zero-based index or header name of the kana reading column divided with the name")
+	c.Flags().Bool(HeaderOption, false, "treat the first record as the header")
+	c.Flags().Bool(InsertOption, false, "insert the divided names right after the name column")
+	c.Flags().Int(WorkersOption, 1, "number of goroutines dividing names (0 means the number of CPUs)")
+	c.Flags().Int(CacheSizeOption, 0, "number of divided names kept for repeated names (0 disables the cache)")
+	return &c
+}
+
+func BuildCandidatesCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "candidates",
+		Short: "It lists the most probable divisions of single full name.",
+		Long: `It lists the most probable divisions of single full name.
+Provide the full name to be parsed with the required flag (--name).
+Each line shows a divided name and its score, ordered by descending score.
+`,
+		Example: "seimei candidates --name 中曽根康弘 --top 3",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := detectFlagForName(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			k, err := detectFlagTop(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return ParseCandidates(cmd.OutOrStdout(), cmd.ErrOrStderr(), n, p, k, opts...)
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().StringP(NameCmd.String(), "n", "", "中曽根康弘")
+	err := c.MarkFlagRequired(NameCmd.String())
+	// since name flag is set on above, it raise panic without returning an error.
+	if err != nil {
+		panic(err)
+	}
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	c.Flags().IntP(TopOption, "k", 3, "number of candidates")
+	addDivisionFlags(&c)
+	return &c
+}
+
+func BuildExplainCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "explain",
+		Short: "It shows how single full name is scored.",
+		Long: `It shows how single full name is scored.
+Provide the full name to be parsed with the required flag (--name).
+The first line is the division. Each split position follows with its scores,
+and each character with its order value, length value, masks and whether it is missing from the table.
+Only the divisions by the statistics parser are explained.
+`,
+		Example: "seimei explain --name 中曽根康弘",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := detectFlagForName(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return ExplainName(cmd.OutOrStdout(), cmd.ErrOrStderr(), n, p, opts...)
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().StringP(NameCmd.String(), "n", "", "中曽根康弘")
+	err := c.MarkFlagRequired(NameCmd.String())
+	// since name flag is set on above, it raise panic without returning an error.
+	if err != nil {
+		panic(err)
+	}
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	return &c
+}
+
+func BuildTrainCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "train",
+		Short: "It builds kanji feature table from divided names.",
+		Long: `It builds kanji feature table from divided names.
+Provide the file path with divided name list to the required flag (--file).
+Each line must be a last name and a first name joined by the parse string.
+The table is printed as CSV in the same format as the embedded kanji.csv.
+With --kana, the kana feature table is built from the divided kana names instead, for --kana-features.
+With --latin, the latin feature table is built from the divided romanized names instead, for --latin-features.
 `,
-		Example: "seimei file --file /path/to/dir/foo.csv",
+		Example: `seimei train --file /path/to/dir/divided.csv > kanji.csv
+seimei train --kana --file /path/to/dir/kana.csv > kana_features.csv
+seimei train --latin --file /path/to/dir/latin.csv > latin_features.csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			f, err := detectFlagForFile(cmd)
 			if err != nil {
@@ -97,20 +353,316 @@ Provide the file path with full name list to the required flag (--file).
 			if err != nil {
 				return fmt.Errorf("flag parse error: %w", err)
 			}
-			return ParseFile(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p)
+			if p == "" {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidParseString)
+			}
+			k, err := cmd.Flags().GetBool(KanaOption)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidKanaPath)
+			}
+			l, err := cmd.Flags().GetBool(LatinOption)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidLatinPath)
+			}
+			if k && l {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidTrain)
+			}
+			if k {
+				return TrainKana(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p)
+			}
+			if l {
+				return TrainLatin(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p)
+			}
+			return Train(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p)
 		},
 	}
 	c.Flags().SortFlags = false
-	c.Flags().StringP(FileCmd.String(), "f", "", "/path/to/dir/foo.csv")
+	c.Flags().StringP(FileCmd.String(), "f", "", "/path/to/dir/divided.csv")
 	err := c.MarkFlagRequired(FileCmd.String())
 	// since file flag is set on above, it raise panic without returning an error.
 	if err != nil {
 		panic(err)
 	}
 	c.Flags().StringP(ParseOption, "p", " ", " ")
+	c.Flags().Bool(KanaOption, false, "build the kana feature table from divided kana names")
+	c.Flags().Bool(LatinOption, false, "build the latin feature table from divided romanized names")
+	return &c
+}
+
+func BuildEvalCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "eval",
+		Short: "It measures accuracy against divided names.",
+		Long: `It measures accuracy against divided names.
+Provide the file path with gold-standard divided name list to the required flag (--file).
+Each line must be a last name and a first name joined by the parse string.
+The accuracy is reported overall, by full name length, by algorithm and by score,
+followed by the misclassified lines.
+`,
+		Example: "seimei eval --file /path/to/dir/gold.csv",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := detectFlagForFile(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			if p == "" {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidParseString)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return EvaluateFile(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, p, opts...)
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().StringP(FileCmd.String(), "f", "", "/path/to/dir/gold.csv")
+	err := c.MarkFlagRequired(FileCmd.String())
+	// since file flag is set on above, it raise panic without returning an error.
+	if err != nil {
+		panic(err)
+	}
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	return &c
+}
+
+func BuildServeCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "serve",
+		Short: "It serves the divider as a JSON API over HTTP.",
+		Long: `It serves the divider as a JSON API over HTTP.
+POST /divide divides {"name": "田中太郎"}, and POST /divide/batch divides a JSON array of them,
+or NDJSON when the Content-Type is application/x-ndjson.
+GET /healthz and GET /readyz report the health and the readiness.
+The server shuts down gracefully on SIGINT or SIGTERM.
+`,
+		Example: "seimei serve --addr :8080",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cmd.Flags().GetString(AddrOption)
+			if err != nil || addr == "" {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidAddr)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			lo, err := detectFlagLimits(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, lo...)
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return Serve(ctx, cmd.ErrOrStderr(), addr, NewServer(p, opts...))
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().String(AddrOption, ":8080", "address to listen on")
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	c.Flags().Int(MaxNameOption, defaultMaxNameLength, "longest name accepted in characters")
+	c.Flags().Int(MaxBatchOption, defaultMaxBatchSize, "most names accepted in a batch")
+	return &c
+}
+
+func BuildGRPCServeCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "grpc-serve",
+		Short: "It serves the divider as a gRPC service.",
+		Long: `It serves the divider as a gRPC service.
+The NameDivider service of namedivider/namedivider.proto has the unary Divide
+and the bidirectional streaming DivideStream. The standard health service is served as well.
+The server stops gracefully on SIGINT or SIGTERM.
+`,
+		Example: "seimei grpc-serve --addr :50051",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := cmd.Flags().GetString(AddrOption)
+			if err != nil || addr == "" {
+				return fmt.Errorf("flag parse error: %w", ErrInvalidAddr)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts, err := divisionOptions(cmd)
+			if err != nil {
+				return err
+			}
+			lo, err := detectFlagLimits(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			opts = append(opts, lo...)
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return ServeGRPC(ctx, cmd.ErrOrStderr(), addr, NewGRPCServer(p, opts...))
+		},
+	}
+	c.Flags().SortFlags = false
+	c.Flags().String(AddrOption, ":50051", "address to listen on")
+	c.Flags().StringP(ParseOption, "p", " ", " ")
+	addDivisionFlags(&c)
+	c.Flags().Int(MaxNameOption, defaultMaxNameLength, "longest name accepted in characters")
+	return &c
+}
+
+func BuildDictCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "dict",
+		Short: "It maintains the dictionary of pinned divisions.",
+		Long: `It maintains the dictionary of pinned divisions.
+Provide the dictionary path to the required flag (--dict), and use it with --dict of the other commands.
+An entry of a last name and a first name pins the division of the full name,
+and an entry of a last name alone divides the full names starting with it.
+`,
+		Example: `seimei dict add --dict /path/to/dir/dict.csv 竈門 炭治郎
+seimei dict add --dict /path/to/dir/dict.csv 勅使河原`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Usage()
+		},
+	}
+	c.PersistentFlags().String(DictOption, "", "/path/to/dir/dict.csv")
+	err := c.MarkPersistentFlagRequired(DictOption)
+	// since dict flag is set on above, it raise panic without returning an error.
+	if err != nil {
+		panic(err)
+	}
+	c.AddCommand(buildDictAddCmd())
+	c.AddCommand(buildDictRemoveCmd())
+	c.AddCommand(buildDictListCmd())
+	return &c
+}
+
+func buildDictAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add last_name [first_name]",
+		Short: "It adds a full name or a surname to the dictionary.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := detectFlagDictPath(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			return AddDictionaryEntry(path, dictionaryEntry(args))
+		},
+	}
+}
+
+func buildDictRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove last_name [first_name]",
+		Short: "It removes a full name or a surname from the dictionary.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := detectFlagDictPath(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			return RemoveDictionaryEntry(path, dictionaryEntry(args))
+		},
+	}
+}
+
+func buildDictListCmd() *cobra.Command {
+	c := cobra.Command{
+		Use:   "list",
+		Short: "It lists the entries of the dictionary.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := detectFlagDictPath(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			p, err := detectFlagParseString(cmd)
+			if err != nil {
+				return fmt.Errorf("flag parse error: %w", err)
+			}
+			return ListDictionary(cmd.OutOrStdout(), path, p)
+		},
+	}
+	c.Flags().StringP(ParseOption, "p", " ", " ")
 	return &c
 }
 
+func dictionaryEntry(args []string) parser.DictionaryEntry {
+	e := parser.DictionaryEntry{LastName: parser.LastName(args[0]), FirstName: ""}
+	if len(args) > 1 {
+		e.FirstName = parser.FirstName(args[1])
+	}
+	return e
+}
+
+// addDivisionFlags registers the flags configuring how names are divided, which every command dividing names shares.
+func addDivisionFlags(c *cobra.Command) {
+	c.Flags().Float64(MinScoreOption, 0, "reject divisions scored lower than this")
+	c.Flags().Float64(MinMarginOption, 0, "reject divisions not ahead of the runner-up by this")
+	c.Flags().String(FeaturesOption, "", "/path/to/dir/kanji.csv")
+	c.Flags().String(KanaTableOption, "", "/path/to/dir/kana_features.csv")
+	c.Flags().String(LatinFeatOption, "", "/path/to/dir/latin_features.csv")
+	c.Flags().String(DictOption, "", "/path/to/dir/dict.csv")
+	c.Flags().StringSlice(AlgorithmOption, nil, algorithmUsage())
+	c.Flags().StringSlice(NormalizeOption, nil, "nfkc, width and/or space applied in order before dividing")
+	c.Flags().Bool(VariantsOption, false, "look up variant kanji missing from the table such as 槗 by their common forms such as 橋")
+	c.Flags().Bool(GivenOption, false, "divide at a delimiter already in the name")
+	c.Flags().String(DelimiterOption, "", "characters dividing the name with --given (default space, U+3000, ・ and comma)")
+	c.Flags().Bool(CheckOption, false, "report names whose delimiter disagrees with the model instead of trusting it")
+}
+
+// divisionOptions returns the options given by the flags of addDivisionFlags.
+func divisionOptions(cmd *cobra.Command) ([]Option, error) {
+	opts, err := detectFlagConfidence(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", err)
+	}
+	fo, err := detectFlagFeatures(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, fo...)
+	ko, err := detectFlagKanaFeatures(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, ko...)
+	la, err := detectFlagLatinFeatures(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, la...)
+	do, err := detectFlagDictionary(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, do...)
+	ao, err := detectFlagAlgorithm(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", err)
+	}
+	opts = append(opts, ao...)
+	no, err := detectFlagNormalize(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", err)
+	}
+	opts = append(opts, no...)
+	if v, err := cmd.Flags().GetBool(VariantsOption); err == nil && v {
+		opts = append(opts, WithVariants(feature.DefaultVariantTable()))
+	}
+	gi, err := detectFlagGiven(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", err)
+	}
+	return append(opts, gi...), nil
+}
+
 func Run() error {
 	cmd := BuildMainCmd()
 	return cmd.Execute()
@@ -128,6 +680,24 @@ func detectFlagForName(cmd *cobra.Command) (Name, error) {
 	return Name(n), nil
 }
 
+// detectFlagForInput returns the path given by the flag or the argument, or Stdin when neither is given.
+func detectFlagForInput(cmd *cobra.Command, args []string) (Path, error) {
+	n, err := cmd.Flags().GetString(FileCmd.String())
+	if err != nil {
+		return "", ErrInvalidPath
+	}
+	if n != "" && len(args) > 0 {
+		return "", ErrDuplicatePath
+	}
+	if len(args) > 0 {
+		n = args[0]
+	}
+	if n == "" {
+		return Stdin, nil
+	}
+	return Path(n), nil
+}
+
 func detectFlagForFile(cmd *cobra.Command) (Path, error) {
 	n, err := cmd.Flags().GetString(FileCmd.String())
 	if err != nil {
@@ -147,3 +717,324 @@ func detectFlagParseString(cmd *cobra.Command) (ParseString, error) {
 	}
 	return ParseString(p), nil
 }
+
+func detectFlagTop(cmd *cobra.Command) (Top, error) {
+	k, err := cmd.Flags().GetInt(TopOption)
+	if err != nil {
+		return 0, ErrInvalidTop
+	}
+	if k < 1 {
+		return 0, ErrInvalidTop
+	}
+	return Top(k), nil
+}
+
+func detectFlagConfidence(cmd *cobra.Command) ([]Option, error) {
+	s, err := cmd.Flags().GetFloat64(MinScoreOption)
+	if err != nil || s < 0 || s > 1 {
+		return nil, ErrInvalidMinScore
+	}
+	m, err := cmd.Flags().GetFloat64(MinMarginOption)
+	if err != nil || m < 0 || m > 1 {
+		return nil, ErrInvalidMinMargin
+	}
+	return []Option{WithMinScore(s), WithMinMargin(m)}, nil
+}
+
+func detectFlagReject(cmd *cobra.Command) (Path, error) {
+	r, err := cmd.Flags().GetString(RejectOption)
+	if err != nil {
+		return "", ErrInvalidRejectPath
+	}
+	return Path(r), nil
+}
+
+// detectFlagFeatures loads the kanji feature table given by the flag.
+// It returns no option when the flag is not set, so that the embedded table is used.
+func detectFlagFeatures(cmd *cobra.Command) ([]Option, error) {
+	path, err := cmd.Flags().GetString(FeaturesOption)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", ErrInvalidFeaturePath)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load features: %w", err)
+	}
+	defer f.Close()
+	m, err := LoadKanjiFeatureManager(f)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load features: %w", err)
+	}
+	return []Option{WithKanjiFeatureManager(m)}, nil
+}
+
+func detectFlagOutput(cmd *cobra.Command) (OutputFormat, error) {
+	o, err := cmd.Flags().GetString(OutputOption)
+	if err != nil {
+		return "", ErrInvalidOutput
+	}
+	f, err := ParseOutputFormat(o)
+	if err != nil {
+		return "", ErrInvalidOutput
+	}
+	return f, nil
+}
+
+func detectFlagColumn(cmd *cobra.Command) ([]Option, error) {
+	var opts []Option
+	c, err := cmd.Flags().GetString(ColumnOption)
+	if err != nil {
+		return nil, ErrInvalidColumnFlag
+	}
+	if c != "" {
+		opts = append(opts, WithColumn(c))
+	}
+	r, err := cmd.Flags().GetString(ReadingOption)
+	if err != nil {
+		return nil, ErrInvalidColumnFlag
+	}
+	if r != "" {
+		opts = append(opts, WithReadingColumn(r))
+	}
+	h, err := cmd.Flags().GetBool(HeaderOption)
+	if err != nil {
+		return nil, ErrInvalidColumnFlag
+	}
+	if h {
+		opts = append(opts, WithHeader())
+	}
+	i, err := cmd.Flags().GetBool(InsertOption)
+	if err != nil {
+		return nil, ErrInvalidColumnFlag
+	}
+	if i {
+		opts = append(opts, WithInsertedColumns())
+	}
+	return opts, nil
+}
+
+func detectFlagWorkers(cmd *cobra.Command) (int, error) {
+	n, err := cmd.Flags().GetInt(WorkersOption)
+	if err != nil {
+		return 0, ErrInvalidWorkers
+	}
+	if n < 0 {
+		return 0, ErrInvalidWorkers
+	}
+	return n, nil
+}
+
+func detectFlagCacheSize(cmd *cobra.Command) (int, error) {
+	n, err := cmd.Flags().GetInt(CacheSizeOption)
+	if err != nil {
+		return 0, ErrInvalidCacheSize
+	}
+	if n < 0 {
+		return 0, ErrInvalidCacheSize
+	}
+	return n, nil
+}
+
+func detectFlagLimits(cmd *cobra.Command) ([]Option, error) {
+	n, err := cmd.Flags().GetInt(MaxNameOption)
+	if err != nil || n < 1 {
+		return nil, ErrInvalidLimit
+	}
+	opts := []Option{WithMaxNameLength(n)}
+	// grpc-serve has no batch, so it has no max-batch-size flag.
+	if cmd.Flags().Lookup(MaxBatchOption) == nil {
+		return opts, nil
+	}
+	b, err := cmd.Flags().GetInt(MaxBatchOption)
+	if err != nil || b < 1 {
+		return nil, ErrInvalidLimit
+	}
+	return append(opts, WithMaxBatchSize(b)), nil
+}
+
+func detectFlagNormalize(cmd *cobra.Command) ([]Option, error) {
+	names, err := cmd.Flags().GetStringSlice(NormalizeOption)
+	if err != nil {
+		return nil, ErrInvalidNormalize
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	ns, err := ParseNormalizers(names)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNormalize, err)
+	}
+	return []Option{WithNormalizers(ns...)}, nil
+}
+
+// detectFlagGiven returns the options dividing at delimiters. --delimiters and --check-given imply --given.
+func detectFlagGiven(cmd *cobra.Command) ([]Option, error) {
+	g, err := cmd.Flags().GetBool(GivenOption)
+	if err != nil {
+		return nil, ErrInvalidGiven
+	}
+	d, err := cmd.Flags().GetString(DelimiterOption)
+	if err != nil {
+		return nil, ErrInvalidGiven
+	}
+	c, err := cmd.Flags().GetBool(CheckOption)
+	if err != nil {
+		return nil, ErrInvalidGiven
+	}
+	var opts []Option
+	if g || d != "" || c {
+		if err := checkGivenNormalize(cmd, []rune(d)); err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithGivenDelimiters([]rune(d)...))
+	}
+	if c {
+		opts = append(opts, WithGivenCheck())
+	}
+	return opts, nil
+}
+
+// checkGivenNormalize rejects --normalize removing the delimiters of --given, such as space removing U+3000,
+// which leaves no delimiter to divide at.
+func checkGivenNormalize(cmd *cobra.Command, ds []rune) error {
+	names, err := cmd.Flags().GetStringSlice(NormalizeOption)
+	if err != nil {
+		return ErrInvalidNormalize
+	}
+	ns, err := ParseNormalizers(names)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidNormalize, err)
+	}
+	if len(ds) == 0 {
+		ds = parser.DefaultDelimiters()
+	}
+	if d, ok := removedDelimiter(ns, ds); ok {
+		return fmt.Errorf("%w: %q", ErrGivenNormalize, d)
+	}
+	return nil
+}
+
+// detectFlagRomanize returns the romanizer option. --long-vowel and --name-order imply --romanize.
+func detectFlagRomanize(cmd *cobra.Command) ([]Option, error) {
+	r, err := cmd.Flags().GetBool(RomanizeOption)
+	if err != nil {
+		return nil, ErrInvalidRomanize
+	}
+	if !r && !cmd.Flags().Changed(LongVowelOption) && !cmd.Flags().Changed(NameOrderOption) {
+		return nil, nil
+	}
+	l, err := cmd.Flags().GetString(LongVowelOption)
+	if err != nil {
+		return nil, ErrInvalidRomanize
+	}
+	v, err := romanize.ParseLongVowel(l)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRomanize, err)
+	}
+	o, err := cmd.Flags().GetString(NameOrderOption)
+	if err != nil {
+		return nil, ErrInvalidRomanize
+	}
+	order, err := romanize.ParseOrder(o)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRomanize, err)
+	}
+	return []Option{WithRomanizer(romanize.NewRomanizer(romanize.WithLongVowel(v), romanize.WithOrder(order)))}, nil
+}
+
+func detectFlagDictPath(cmd *cobra.Command) (Path, error) {
+	path, err := cmd.Flags().GetString(DictOption)
+	if err != nil || path == "" {
+		return "", ErrInvalidDictPath
+	}
+	return Path(path), nil
+}
+
+// detectFlagDictionary loads the dictionary given by the flag.
+// It returns no option when the flag is not set, so that no division is pinned.
+func detectFlagDictionary(cmd *cobra.Command) ([]Option, error) {
+	path, err := cmd.Flags().GetString(DictOption)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", ErrInvalidDictPath)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load dictionary: %w", err)
+	}
+	defer f.Close()
+	d, err := LoadNameDictionary(f)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load dictionary: %w", err)
+	}
+	return []Option{WithDictionary(d)}, nil
+}
+
+// algorithmUsage lists the registered algorithms, so that the help follows parser.RegisterParser.
+func algorithmUsage() string {
+	return fmt.Sprintf("parsers tried in order among %s (default latin,foreign,rule,statistics)", joinAlgorithms(parser.Algorithms()))
+}
+
+func detectFlagAlgorithm(cmd *cobra.Command) ([]Option, error) {
+	names, err := cmd.Flags().GetStringSlice(AlgorithmOption)
+	if err != nil {
+		return nil, ErrInvalidAlgorithm
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	as, err := ParseAlgorithms(names)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAlgorithm, err)
+	}
+	return []Option{WithAlgorithms(as...)}, nil
+}
+
+// detectFlagKanaFeatures loads the kana feature table given by the flag.
+// It returns no option when the flag is not set, so that the embedded kana names are used.
+func detectFlagKanaFeatures(cmd *cobra.Command) ([]Option, error) {
+	path, err := cmd.Flags().GetString(KanaTableOption)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", ErrInvalidKanaPath)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load kana features: %w", err)
+	}
+	defer f.Close()
+	m, err := LoadKanaModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load kana features: %w", err)
+	}
+	return []Option{WithKanaModel(m)}, nil
+}
+
+// detectFlagLatinFeatures loads the latin feature table given by the flag.
+// It returns no option when the flag is not set, so that the embedded romanized names are used.
+func detectFlagLatinFeatures(cmd *cobra.Command) ([]Option, error) {
+	path, err := cmd.Flags().GetString(LatinFeatOption)
+	if err != nil {
+		return nil, fmt.Errorf("flag parse error: %w", ErrInvalidLatinPath)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load latin features: %w", err)
+	}
+	defer f.Close()
+	m, err := LoadLatinModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("happen error load latin features: %w", err)
+	}
+	return []Option{WithLatinModel(m)}, nil
+}
@@ -3,6 +3,7 @@ package seimei_test
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/glassmonkey/seimei/v2"
@@ -34,6 +35,166 @@ func TestBuildNameCmd(t *testing.T) {
 			input:   []string{"-n", "田中太郎", "-p", "/"},
 			wantOut: "田中/太郎\n",
 		},
+		{
+			name:       "スコアが閾値未満",
+			input:      []string{"--name", "竈門炭治郎", "--min-score", "0.5"},
+			wantErrOut: "parse error: low confidence division: best=竈門 炭治郎, score=0.2473, margin=0.0276\n",
+		},
+		{
+			name:    "特徴量の表を指定",
+			input:   []string{"--name", "田中太郎", "--features", "./testdata/kanji.csv"},
+			wantOut: "田 中太郎\n",
+		},
+		{
+			name:       "不正な特徴量の表",
+			input:      []string{"--name", "田中太郎", "--features", "./testdata/invalid_kanji.csv"},
+			wantErrMsg: "happen error load features: invalid kanji feature table: line 1: header of kanji feature table is invalid: [kanji oc_family_first]",
+		},
+		{
+			name:    "出力形式の指定",
+			input:   []string{"--name", "乙一", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n乙一\t乙\t一\t\t1\trule\t\n",
+		},
+		{
+			name:       "未定義の出力形式",
+			input:      []string{"--name", "乙一", "--output", "xml"},
+			wantErrMsg: "flag parse error: provide output is invalid (ex. json)",
+		},
+		{
+			name:    "正規化の指定",
+			input:   []string{"--name", "ﾔﾏﾀﾞ　太郎", "--normalize", "nfkc,space", "--output", "jsonl"},
+			wantOut: `{"input":"ﾔﾏﾀﾞ　太郎","last_name":"ヤマダ","first_name":"太郎","score":1,"algorithm":"rule","error":"","normalized":"ヤマダ太郎"}` + "\n",
+		},
+		{
+			name:    "異体字の同一視",
+			input:   []string{"--name", "槗本彩", "--fold-variants", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n槗本彩\t槗本\t彩\t\t0.5294912395314353\tstatistics\t\n",
+		},
+		{
+			name:    "統計量だけで2文字を分割する",
+			input:   []string{"--name", "乙一", "--algorithm", "statistics", "-o", "jsonl"},
+			wantOut: `{"input":"乙一","last_name":"乙","first_name":"一","score":0.5621765008857981,"algorithm":"statistics","error":""}` + "\n",
+		},
+		{
+			name:    "区切りの利用",
+			input:   []string{"--name", "菅義 偉", "--given"},
+			wantOut: "菅義 偉\n",
+		},
+		{
+			name:    "区切り文字の指定",
+			input:   []string{"--name", "菅義/偉", "--delimiters", "/", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n菅義/偉\t菅義\t偉\t\t1\tgiven\t\n",
+		},
+		{
+			name:    "区切りと正規化の併用",
+			input:   []string{"--name", "竈門　炭治郎", "--given", "--normalize", "nfkc", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n竈門　炭治郎\t竈門\t炭治郎\t\t1\tgiven\t\n",
+		},
+		{
+			name:    "空白以外の区切りと空白の正規化の併用",
+			input:   []string{"--name", "竈門・炭治郎", "--delimiters", "・", "--normalize", "space", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n竈門・炭治郎\t竈門\t炭治郎\t\t1\tgiven\t\n",
+		},
+		{
+			name:       "区切りを消す正規化",
+			input:      []string{"--name", "竈門　炭治郎", "--given", "--normalize", "space"},
+			wantErrMsg: `flag parse error: provide normalize keeping the delimiters of given (ex. --normalize nfkc): ' '`,
+		},
+		{
+			name:       "区切りとモデルの不一致",
+			input:      []string{"--name", "菅義 偉", "--check-given"},
+			wantErrOut: "parse error: given division disagrees with the model: given=菅義 偉, model=菅 義偉\n",
+		},
+		{
+			name:    "辞書の指定",
+			input:   []string{"--name", "勅使河原三郎", "--dict", "./testdata/dict.csv", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n勅使河原三郎\t勅使河原\t三郎\t\t1\tdictionary\t\n",
+		},
+		{
+			name:       "不正な辞書",
+			input:      []string{"--name", "田中太郎", "--dict", "./testdata/invalid_dict.csv"},
+			wantErrMsg: "happen error load dictionary: invalid dictionary: line 3: last name of dictionary must not be empty\nline 4: name is duplicated: 中曽根康弘 is already defined on line 2",
+		},
+		{
+			name:    "かな素性表の指定",
+			input:   []string{"--name", "かたた", "--kana-features", "./testdata/kana_features.csv", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\nかたた\tか\tたた\t\t0.8387096774193549\tkana\t\n",
+		},
+		{
+			name:       "存在しないかな素性表",
+			input:      []string{"--name", "かたた", "--kana-features", "./testdata/nothing.csv"},
+			wantErrMsg: "happen error load kana features: open ./testdata/nothing.csv: no such file or directory",
+		},
+		{
+			name:    "ラテン文字の名前",
+			input:   []string{"--name", "Hanako SATO", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\nHanako SATO\tSATO\tHanako\t\t1\tlatin\t\n",
+		},
+		{
+			name:    "ラテン文字素性表の指定",
+			input:   []string{"--name", "Taro Yamada", "--latin-features", "./testdata/latin_features.csv", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\nTaro Yamada\tTaro\tYamada\t\t0.8\tlatin\t\n",
+		},
+		{
+			name:       "存在しないラテン文字素性表",
+			input:      []string{"--name", "Taro Yamada", "--latin-features", "./testdata/nothing.csv"},
+			wantErrMsg: "happen error load latin features: open ./testdata/nothing.csv: no such file or directory",
+		},
+		{
+			name:    "カタカナの外国人名",
+			input:   []string{"--name", "マリア・テレサ・ロペス"},
+			wantOut: "ロペス テレサ マリア\n",
+		},
+		{
+			name:    "外国人名のミドルネームの列",
+			input:   []string{"--name", "マリア・テレサ・ロペス", "--output", "csv"},
+			wantOut: "input,last_name,first_name,middle_name,score,algorithm,error\nマリア・テレサ・ロペス,ロペス,マリア,テレサ,1,foreign,\n",
+		},
+		{
+			name:    "外国人名のミドルネーム",
+			input:   []string{"--name", "マリア・テレサ・ロペス", "--output", "jsonl"},
+			wantOut: `{"input":"マリア・テレサ・ロペス","last_name":"ロペス","first_name":"マリア","middle_name":"テレサ","score":1,"algorithm":"foreign","error":""}` + "\n",
+		},
+		{
+			name:    "ローマ字表記",
+			input:   []string{"--name", "やまだはなこ", "--algorithm", "kana", "--romanize"},
+			wantOut: "YAMADA Hanako\n",
+		},
+		{
+			name:    "長音記号と名姓の順",
+			input:   []string{"--name", "おおのようこ", "--algorithm", "kana", "--long-vowel", "macron", "--name-order", "given-family", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\tromanized\nおおのようこ\tおおの\tようこ\t\t0.9978584357759835\tkana\t\tYōko ŌNO\n",
+		},
+		{
+			name:       "かなでない名前のローマ字表記",
+			input:      []string{"--name", "田中太郎", "--romanize"},
+			wantErrOut: "romanize error: name must consist of kana to be romanized: 田中\n",
+		},
+		{
+			name:       "未定義の長音の表記",
+			input:      []string{"--name", "やまだはなこ", "--long-vowel", "hat"},
+			wantErrMsg: `flag parse error: provide romanize is invalid (ex. --long-vowel macron --name-order given-family): long vowel must be one of omit, macron and keep: "hat"`,
+		},
+		{
+			name:    "アルゴリズムの指定",
+			input:   []string{"--name", "中山マサ", "--algorithm", "statistics", "--output", "tsv"},
+			wantOut: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n中山マサ\t中山\tマサ\t\t0.3528141020006961\tstatistics\t\n",
+		},
+		{
+			name:       "未定義のアルゴリズム",
+			input:      []string{"--name", "中山マサ", "--algorithm", "rule,neural"},
+			wantErrMsg: `flag parse error: provide algorithm is invalid (ex. rule,statistics): algorithm is not registered: "neural" (registered: foreign, kana, latin, rule, statistics)`,
+		},
+		{
+			name:       "未定義の正規化",
+			input:      []string{"--name", "田中太郎", "--normalize", "nfc"},
+			wantErrMsg: `flag parse error: provide normalize is invalid (ex. nfkc,space): normalizer must be one of nfkc, width and space: "nfc"`,
+		},
+		{
+			name:       "閾値が範囲外",
+			input:      []string{"--name", "田中太郎", "--min-score", "1.5"},
+			wantErrMsg: "flag parse error: provide min score is invalid (ex. 0.5)",
+		},
 		{
 			name:       "指定がない",
 			input:      []string{"--name"},
@@ -94,6 +255,7 @@ func TestBuildFileCmd(t *testing.T) {
 	type testdata struct {
 		name       string
 		input      []string
+		inputStdin string
 		wantOut    string
 		wantErrOut string
 		wantErrMsg string
@@ -137,6 +299,115 @@ func TestBuildFileCmd(t *testing.T) {
 			wantErrOut: `parse error on line 2: parse error: name length needs at least 2 chars
 `,
 		},
+		{
+			name:  "特徴量の表を指定",
+			input: []string{"--file", "./testdata/success.csv", "--features", "./testdata/kanji.csv"},
+			wantOut: `田 中太郎
+乙 一
+竈門 炭治郎
+中 曽根康弘
+`,
+		},
+		{
+			name:  "出力形式の指定",
+			input: []string{"-f", "./testdata/part_of_error.csv", "-o", "jsonl"},
+			wantOut: `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}
+{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"parse error: name length needs at least 2 chars"}
+{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":""}
+{"input":"中曽根康弘","last_name":"中曽根","first_name":"康弘","score":0.3127240879300895,"algorithm":"statistics","error":""}
+`,
+		},
+		{
+			name:  "列の指定",
+			input: []string{"-f", "./testdata/multi_column.csv", "--header", "--column", "name"},
+			wantOut: `id,name,email,last_name,first_name,middle_name
+1,田中太郎,tanaka@example.com,田中,太郎,
+2,乙,otsu@example.com,,,
+3,竈門炭治郎,"kamado,tanjiro@example.com",竈門,炭治郎,
+`,
+			wantErrOut: "parse error on line 3: parse error: name length needs at least 2 chars\n",
+		},
+		{
+			name:  "読みの列の指定",
+			input: []string{"-f", "./testdata/reading_pairs.csv", "--reading-column", "1"},
+			wantOut: `竈門炭治郎,カマドタンジロウ,竈門,炭治郎,,カマド,タンジロウ
+胡蝶しのぶ,こちょうしのぶ,胡蝶,しのぶ,,こちょう,しのぶ
+`,
+		},
+		{
+			name:  "読みのローマ字表記",
+			input: []string{"-f", "./testdata/reading_pairs.csv", "--reading-column", "1", "--romanize"},
+			wantOut: `竈門炭治郎,カマドタンジロウ,竈門,炭治郎,,カマド,タンジロウ,KAMADO Tanjiro
+胡蝶しのぶ,こちょうしのぶ,胡蝶,しのぶ,,こちょう,しのぶ,KOCHO Shinobu
+`,
+		},
+		{
+			name:  "読みの列のJSON出力",
+			input: []string{"-f", "./testdata/reading_pairs.csv", "--reading-column", "1", "-o", "json"},
+			wantOut: `[
+{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":"","last_name_reading":"カマド","first_name_reading":"タンジロウ"},
+{"input":"胡蝶しのぶ","last_name":"胡蝶","first_name":"しのぶ","score":1,"algorithm":"rule","error":"","last_name_reading":"こちょう","first_name_reading":"しのぶ"}
+]
+`,
+		},
+		{
+			name:  "並列数の指定",
+			input: []string{"-f", "./testdata/part_of_error.csv", "--workers", "4"},
+			wantOut: `田中 太郎
+竈門 炭治郎
+中曽根 康弘
+`,
+			wantErrOut: `parse error on line 2: parse error: name length needs at least 2 chars
+`,
+		},
+		{
+			name:  "キャッシュの指定",
+			input: []string{"-f", "./testdata/success.csv", "--cache-size", "2"},
+			wantOut: `田中 太郎
+乙 一
+竈門 炭治郎
+中曽根 康弘
+`,
+			wantErrOut: "cache hits=0 misses=4\n",
+		},
+		{
+			name:  "区切りの有無が混ざる場合",
+			input: []string{"-f", "./testdata/given.csv", "--given"},
+			wantOut: `竈門 炭治郎
+菅義 偉
+田中 太郎
+中曽根 康弘
+`,
+		},
+		{
+			name:  "区切りとモデルの不一致を報告",
+			input: []string{"-f", "./testdata/given.csv", "--check-given"},
+			wantOut: `竈門 炭治郎
+田中 太郎
+中曽根 康弘
+`,
+			wantErrOut: `parse error on line 2: parse error: given division disagrees with the model: given=菅義 偉, model=菅 義偉
+`,
+		},
+		{
+			name:  "辞書の指定",
+			input: []string{"-f", "./testdata/success.csv", "--dict", "./testdata/dict.csv", "-o", "jsonl"},
+			wantOut: `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}
+{"input":"乙一","last_name":"乙","first_name":"一","score":1,"algorithm":"rule","error":""}
+{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":""}
+{"input":"中曽根康弘","last_name":"中曽根","first_name":"康弘","score":1,"algorithm":"dictionary","error":""}
+`,
+		},
+		{
+			name:       "キャッシュの大きさが負",
+			input:      []string{"-f", "./testdata/success.csv", "--cache-size", "-1"},
+			wantErrMsg: "flag parse error: provide cache size is invalid (ex. 10000)",
+		},
+		{
+			name:       "並列数が負",
+			input:      []string{"-f", "./testdata/success.csv", "--workers", "-1"},
+			wantErrMsg: "flag parse error: provide workers is invalid (ex. 4)",
+		},
 		{
 			name:       "指定がない",
 			input:      []string{"--file"},
@@ -153,9 +424,33 @@ func TestBuildFileCmd(t *testing.T) {
 			wantErrMsg: "unknown flag: --any",
 		},
 		{
-			name:       "空",
+			name:    "引数でのファイル指定",
+			input:   []string{"./testdata/success.csv", "-p", "@"},
+			wantOut: "田中@太郎\n乙@一\n竈門@炭治郎\n中曽根@康弘\n",
+		},
+		{
+			name:       "ハイフン指定は標準入力",
+			input:      []string{"-", "-o", "csv"},
+			inputStdin: "田中太郎\n乙一\n",
+			wantOut:    "input,last_name,first_name,middle_name,score,algorithm,error\n田中太郎,田中,太郎,,0.319858925466683,statistics,\n乙一,乙,一,,1,rule,\n",
+		},
+		{
+			name:       "フラグでのハイフン指定は標準入力",
+			input:      []string{"--file", "-"},
+			inputStdin: "田中太郎\n乙\n",
+			wantOut:    "田中 太郎\n",
+			wantErrOut: "parse error on line 2: parse error: name length needs at least 2 chars\n",
+		},
+		{
+			name:       "フラグと引数の両方",
+			input:      []string{"--file", "./testdata/success.csv", "-"},
+			wantErrMsg: "flag parse error: provide path either as the flag or as the argument",
+		},
+		{
+			name:       "空は標準入力",
 			input:      []string{},
-			wantErrMsg: "required flag(s) \"file\" not set",
+			inputStdin: "竈門炭治郎\n",
+			wantOut:    "竈門 炭治郎\n",
 		},
 	}
 	for _, tt := range tests {
@@ -167,6 +462,77 @@ func TestBuildFileCmd(t *testing.T) {
 			sut := seimei.BuildFileCmd()
 			sut.SetOut(stdout)
 			sut.SetErr(stderr)
+			sut.SetIn(strings.NewReader(tt.inputStdin))
+			sut.SetArgs(tt.input)
+
+			gotErr := sut.Execute()
+			if tt.wantErrMsg == "" && gotErr != nil {
+				t.Fatalf("happen error: %v", gotErr)
+			}
+			if tt.wantErrMsg != "" {
+				if gotErr == nil {
+					t.Fatal("happen no error")
+				}
+				if diff := cmp.Diff(gotErr.Error(), tt.wantErrMsg); diff != "" {
+					t.Fatalf("failed to test on error. diff: %s", diff)
+				}
+				return
+			}
+			if diff := cmp.Diff(stdout.String(), tt.wantOut); diff != "" {
+				t.Errorf("failed to test on error. diff: %s", diff)
+			}
+			if diff := cmp.Diff(stderr.String(), tt.wantErrOut); diff != "" {
+				t.Errorf("failed to test on error. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCandidatesCmd(t *testing.T) {
+	type testdata struct {
+		name       string
+		input      []string
+		wantOut    string
+		wantErrOut string
+		wantErrMsg string
+	}
+
+	tests := []testdata{
+		{
+			name:    "基本",
+			input:   []string{"--name", "中曽根康弘"},
+			wantOut: "中曽根 康弘\t0.3127\n中曽 根康弘\t0.2073\n中曽根康 弘\t0.1679\n",
+		},
+		{
+			name:    "候補数指定",
+			input:   []string{"--name", "中曽根康弘", "--top", "1", "--parse", "@"},
+			wantOut: "中曽根@康弘\t0.3127\n",
+		},
+		{
+			name:    "短縮指定でも良い",
+			input:   []string{"-n", "中曽根康弘", "-k", "1", "-p", "/"},
+			wantOut: "中曽根/康弘\t0.3127\n",
+		},
+		{
+			name:       "候補数が0",
+			input:      []string{"--name", "中曽根康弘", "--top", "0"},
+			wantErrMsg: "flag parse error: provide top is invalid (ex. 3)",
+		},
+		{
+			name:       "空",
+			input:      []string{},
+			wantErrMsg: "required flag(s) \"name\" not set",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			sut := seimei.BuildCandidatesCmd()
+			sut.SetOut(stdout)
+			sut.SetErr(stderr)
 			sut.SetArgs(tt.input)
 
 			gotErr := sut.Execute()
@@ -222,11 +588,47 @@ Examples:
 seimei name --name 田中太郎
 
 Flags:
-  -n, --name string    田中太郎
-  -p, --parse string     (default " ")
-  -h, --help           help for name
+  -n, --name string             田中太郎
+  -p, --parse string              (default " ")
+      --min-score float         reject divisions scored lower than this
+      --min-margin float        reject divisions not ahead of the runner-up by this
+      --features string         /path/to/dir/kanji.csv
+      --kana-features string    /path/to/dir/kana_features.csv
+      --latin-features string   /path/to/dir/latin_features.csv
+      --dict string             /path/to/dir/dict.csv
+      --algorithm strings       parsers tried in order among foreign, kana, latin, rule, statistics (default latin,foreign,rule,statistics)
+      --normalize strings       nfkc, width and/or space applied in order before dividing
+      --fold-variants           look up variant kanji missing from the table such as 槗 by their common forms such as 橋
+      --given                   divide at a delimiter already in the name
+      --delimiters string       characters dividing the name with --given (default space, U+3000, ・ and comma)
+      --check-given             report names whose delimiter disagrees with the model instead of trusting it
+      --romanize                write kana names in modified Hepburn such as YAMADA Hanako
+      --long-vowel string       long vowels with --romanize: omit, macron or keep (default "omit")
+      --name-order string       order with --romanize: family-given or given-family (default "family-given")
+  -o, --output string           text, json, jsonl, csv or tsv (default "text")
+  -h, --help                    help for name
 `,
 		},
+		{
+			name:    "候補の列挙",
+			input:   []string{"candidates", "--name", "中曽根康弘", "--top", "2"},
+			wantOut: "中曽根 康弘\t0.3127\n中曽 根康弘\t0.2073\n",
+		},
+		{
+			name:    "候補の列挙で区切り文字を使う",
+			input:   []string{"candidates", "--name", "中曽根　康弘", "--given"},
+			wantOut: "中曽根 康弘\t1.0000\n",
+		},
+		{
+			name:    "スコアの説明",
+			input:   []string{"explain", "--name", "乙一"},
+			wantOut: "乙 一\trule\t1.0000\nthe rule parser gives no explanation\n",
+		},
+		{
+			name:    "スコアの説明で正規化する",
+			input:   []string{"explain", "--name", "乙　一", "--normalize", "space"},
+			wantOut: "乙 一\trule\t1.0000\nthe rule parser gives no explanation\n",
+		},
 		{
 			name:  "ファイル経由の実行",
 			input: []string{"file", "--file", "testdata/success.csv"},
@@ -240,18 +642,44 @@ Flags:
 			name:  "ファイル経由の実行のヘルプ",
 			input: []string{"file", "-h"},
 			wantOut: `It bulk parse full name lit in the file.
-Provide the file path with full name list to the required flag (--file).
+Provide the file path with full name list to the flag (--file) or as the argument.
+When the path is "-" or not provided, the full names are read from stdin.
 
 Usage:
-  seimei file [flags]
+  seimei file [path] [flags]
 
 Examples:
 seimei file --file /path/to/dir/foo.csv
+cut -f2 users.tsv | seimei file -
+seimei file --file users.csv --header --column name --reading-column kana
 
 Flags:
-  -f, --file string    /path/to/dir/foo.csv
-  -p, --parse string     (default " ")
-  -h, --help           help for file
+  -f, --file string             /path/to/dir/foo.csv (default stdin)
+  -p, --parse string              (default " ")
+      --min-score float         reject divisions scored lower than this
+      --min-margin float        reject divisions not ahead of the runner-up by this
+      --features string         /path/to/dir/kanji.csv
+      --kana-features string    /path/to/dir/kana_features.csv
+      --latin-features string   /path/to/dir/latin_features.csv
+      --dict string             /path/to/dir/dict.csv
+      --algorithm strings       parsers tried in order among foreign, kana, latin, rule, statistics (default latin,foreign,rule,statistics)
+      --normalize strings       nfkc, width and/or space applied in order before dividing
+      --fold-variants           look up variant kanji missing from the table such as 槗 by their common forms such as 橋
+      --given                   divide at a delimiter already in the name
+      --delimiters string       characters dividing the name with --given (default space, U+3000, ・ and comma)
+      --check-given             report names whose delimiter disagrees with the model instead of trusting it
+      --reject string           /path/to/dir/reject.csv
+      --romanize                write kana names in modified Hepburn such as YAMADA Hanako
+      --long-vowel string       long vowels with --romanize: omit, macron or keep (default "omit")
+      --name-order string       order with --romanize: family-given or given-family (default "family-given")
+  -o, --output string           text, json, jsonl, csv or tsv (default "text")
+  -c, --column string           zero-based index or header name of the name column
+      --reading-column string   zero-based index or header name of the kana reading column divided with the name
+      --header                  treat the first record as the header
+      --insert                  insert the divided names right after the name column
+      --workers int             number of goroutines dividing names (0 means the number of CPUs) (default 1)
+      --cache-size int          number of divided names kept for repeated names (0 disables the cache)
+  -h, --help                    help for file
 `,
 		},
 		{
@@ -264,6 +692,13 @@ Flags:
 Available Commands:
   name        It parse single full name.
   file        It bulk parse full name lit in the file.
+  candidates  It lists the most probable divisions of single full name.
+  explain     It shows how single full name is scored.
+  train       It builds kanji feature table from divided names.
+  eval        It measures accuracy against divided names.
+  serve       It serves the divider as a JSON API over HTTP.
+  grpc-serve  It serves the divider as a gRPC service.
+  dict        It maintains the dictionary of pinned divisions.
   help        Help about any command
 
 Flags:
@@ -283,6 +718,13 @@ Use "seimei [command] --help" for more information about a command.
 Available Commands:
   name        It parse single full name.
   file        It bulk parse full name lit in the file.
+  candidates  It lists the most probable divisions of single full name.
+  explain     It shows how single full name is scored.
+  train       It builds kanji feature table from divided names.
+  eval        It measures accuracy against divided names.
+  serve       It serves the divider as a JSON API over HTTP.
+  grpc-serve  It serves the divider as a gRPC service.
+  dict        It maintains the dictionary of pinned divisions.
   help        Help about any command
 
 Flags:
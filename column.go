@@ -0,0 +1,155 @@
+package seimei
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const defaultNameColumnIndex = 0
+
+var (
+	ErrUnknownColumn      = errors.New("column is not found in the header")
+	ErrInvalidColumn      = errors.New("column must be a zero-based index or a header name")
+	ErrColumnOutputFormat = errors.New("column mode supports only text, csv and tsv output")
+	ErrColumnOutOfRecord  = errors.New("record has no such column")
+)
+
+var (
+	nameColumnHeader    = []string{"last_name", "first_name", "middle_name"}
+	readingColumnHeader = []string{"last_name_reading", "first_name_reading"}
+)
+
+// resolveColumn returns the index of column, which is a header name or a zero-based index.
+// A header name takes precedence over an index.
+func resolveColumn(column string, header []string) (int, error) {
+	if column == "" {
+		return defaultNameColumnIndex, nil
+	}
+
+	for i, h := range header {
+		if h == column {
+			return i, nil
+		}
+	}
+
+	i, err := strconv.Atoi(column)
+	if err != nil {
+		if header != nil {
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColumn, column)
+	}
+
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColumn, column)
+	}
+
+	return i, nil
+}
+
+// columnResultWriter writes each original record with the last name, the first name and the middle name added.
+// The names are appended to the record, or inserted right after the name column.
+// With reading, the divided reading follows the names.
+// A record which fails to be divided is written with empty names, and the error goes to stderr.
+// Each record is flushed as soon as it is written.
+type columnResultWriter struct {
+	w      *csv.Writer
+	stderr io.Writer
+	index  int
+	insert bool
+	// reading adds the last name and the first name of the reading, and romanized adds the romanisation.
+	reading   bool
+	romanized bool
+}
+
+func newColumnResultWriter(out, stderr io.Writer, f OutputFormat, insert bool) (*columnResultWriter, error) {
+	w := csv.NewWriter(out)
+
+	switch f {
+	case TextFormat, CSVFormat:
+	case TSVFormat:
+		w.Comma = '\t'
+	case JSONFormat, JSONLFormat:
+		return nil, fmt.Errorf("%w: %s", ErrColumnOutputFormat, f)
+	}
+
+	return &columnResultWriter{
+		w:         w,
+		stderr:    stderr,
+		index:     defaultNameColumnIndex,
+		insert:    insert,
+		reading:   false,
+		romanized: false,
+	}, nil
+}
+
+func (w *columnResultWriter) writeHeader(header []string) error {
+	names := nameColumnHeader
+	if w.reading {
+		names = append(append([]string{}, nameColumnHeader...), readingColumnHeader...)
+	}
+
+	if w.romanized {
+		names = append(append([]string{}, names...), romanizedHeader)
+	}
+
+	if err := w.w.Write(w.merge(header, names)); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func (w *columnResultWriter) write(d division) error {
+	names := []string{string(d.name.LastName), string(d.name.FirstName), string(d.name.MiddleName)}
+	if w.reading {
+		names = append(names, string(d.reading.LastName), string(d.reading.FirstName))
+	}
+
+	if w.romanized {
+		names = append(names, d.romanized)
+	}
+
+	if d.err != nil {
+		if _, err := fmt.Fprintf(w.stderr, "parse error on line %d: %v\n", d.line, d.err); err != nil {
+			return fmt.Errorf("happen error write stderr: %w", err)
+		}
+
+		names = make([]string, len(names))
+	}
+
+	if err := w.w.Write(w.merge(d.record, names)); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return w.flush()
+}
+
+func (w *columnResultWriter) merge(record, names []string) []string {
+	r := make([]string, 0, len(record)+len(names))
+
+	if !w.insert {
+		r = append(r, record...)
+
+		return append(r, names...)
+	}
+
+	r = append(r, record[:w.index+1]...)
+	r = append(r, names...)
+
+	return append(r, record[w.index+1:]...)
+}
+
+func (w *columnResultWriter) flush() error {
+	w.w.Flush()
+
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
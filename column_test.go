@@ -0,0 +1,189 @@
+package seimei_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFile_Column(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		inputPath  seimei.Path
+		inputOpts  []seimei.Option
+		want       string
+		wantErrOut string
+		wantErr    error
+	}
+
+	tests := []testdata{
+		{
+			name:      "ヘッダー名で列を指定して末尾に追加する",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithHeader()},
+			want: `id,name,email,last_name,first_name,middle_name
+1,田中太郎,tanaka@example.com,田中,太郎,
+2,乙,otsu@example.com,,,
+3,竈門炭治郎,"kamado,tanjiro@example.com",竈門,炭治郎,
+`,
+			wantErrOut: "parse error on line 3: parse error: name length needs at least 2 chars\n",
+		},
+		{
+			name:      "番号で列を指定して名前の列の直後に挿入する",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{
+				seimei.WithColumn("1"), seimei.WithHeader(), seimei.WithInsertedColumns(), seimei.WithOutputFormat(seimei.TSVFormat),
+			},
+			want: "id\tname\tlast_name\tfirst_name\tmiddle_name\temail\n" +
+				"1\t田中太郎\t田中\t太郎\t\ttanaka@example.com\n" +
+				"2\t乙\t\t\t\totsu@example.com\n" +
+				"3\t竈門炭治郎\t竈門\t炭治郎\t\tkamado,tanjiro@example.com\n",
+			wantErrOut: "parse error on line 3: parse error: name length needs at least 2 chars\n",
+		},
+		{
+			name:      "ヘッダーなしで番号指定",
+			inputPath: "testdata/success.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("0")},
+			want: `田中太郎,田中,太郎,
+乙一,乙,一,
+竈門炭治郎,竈門,炭治郎,
+中曽根康弘,中曽根,康弘,
+`,
+		},
+		{
+			name:      "1列のファイルでもヘッダーを読み飛ばす",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithHeader()},
+			wantErrOut: `format error on line 2: [1 田中太郎 tanaka@example.com]
+format error on line 3: [2 乙 otsu@example.com]
+format error on line 4: [3 竈門炭治郎 kamado,tanjiro@example.com]
+`,
+		},
+		{
+			name:      "外国人名のミドルネームを挿入する",
+			inputPath: "testdata/foreign.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithHeader(), seimei.WithInsertedColumns()},
+			want: `id,name,last_name,first_name,middle_name
+1,マリア・テレサ・ロペス,ロペス,マリア,テレサ
+`,
+		},
+		{
+			name:      "レコードにない列",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("3")},
+			want:      "",
+			wantErrOut: `format error on line 1: record has no such column: [id name email]
+format error on line 2: record has no such column: [1 田中太郎 tanaka@example.com]
+format error on line 3: record has no such column: [2 乙 otsu@example.com]
+format error on line 4: record has no such column: [3 竈門炭治郎 kamado,tanjiro@example.com]
+`,
+		},
+		{
+			name:      "読みの列を名前と一緒に分割する",
+			inputPath: "testdata/reading.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithReadingColumn("kana"), seimei.WithHeader()},
+			want: `id,name,kana,last_name,first_name,middle_name,last_name_reading,first_name_reading
+1,竈門炭治郎,カマドタンジロウ,竈門,炭治郎,,カマド,タンジロウ
+2,中山マサ,なかやままさ,中山,マサ,,なかやま,まさ
+3,田中マサ,たなかはなこ,,,,,
+4,我妻善逸,,,,,,
+`,
+			wantErrOut: `parse error on line 4: reading cannot be reconciled with the name: name=田中 マサ, reading=た なかはなこ
+parse error on line 5: failed reading: parse error: name length needs at least 2 chars
+`,
+		},
+		{
+			name:      "読みの列だけ指定すると先頭の列を名前とする",
+			inputPath: "testdata/reading_pairs.csv",
+			inputOpts: []seimei.Option{seimei.WithReadingColumn("1"), seimei.WithOutputFormat(seimei.TSVFormat)},
+			want: "竈門炭治郎\tカマドタンジロウ\t竈門\t炭治郎\t\tカマド\tタンジロウ\n" +
+				"胡蝶しのぶ\tこちょうしのぶ\t胡蝶\tしのぶ\t\tこちょう\tしのぶ\n",
+		},
+		{
+			name:      "読みの列をJSON Linesで書く",
+			inputPath: "testdata/reading.csv",
+			inputOpts: []seimei.Option{
+				seimei.WithColumn("name"), seimei.WithReadingColumn("kana"), seimei.WithHeader(), seimei.WithOutputFormat(seimei.JSONLFormat),
+			},
+			want: `{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":"","last_name_reading":"カマド","first_name_reading":"タンジロウ"}
+{"input":"中山マサ","last_name":"中山","first_name":"マサ","score":1,"algorithm":"rule","error":"","last_name_reading":"なかやま","first_name_reading":"まさ"}
+{"input":"田中マサ","last_name":"","first_name":"","score":0,"algorithm":"","error":"reading cannot be reconciled with the name: name=田中 マサ, reading=た なかはなこ"}
+{"input":"我妻善逸","last_name":"","first_name":"","score":0,"algorithm":"","error":"failed reading: parse error: name length needs at least 2 chars"}
+`,
+		},
+		{
+			name:      "ヘッダーにない読みの列名",
+			inputPath: "testdata/reading.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithReadingColumn("yomi"), seimei.WithHeader()},
+			wantErr:   seimei.ErrUnknownColumn,
+		},
+		{
+			name:      "ヘッダーにない列名",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("fullname"), seimei.WithHeader()},
+			wantErr:   seimei.ErrUnknownColumn,
+		},
+		{
+			name:      "ヘッダーなしで列名指定",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name")},
+			wantErr:   seimei.ErrInvalidColumn,
+		},
+		{
+			name:      "ヘッダーにない列",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("3"), seimei.WithHeader()},
+			wantErr:   seimei.ErrColumnOutOfRecord,
+		},
+		{
+			name:      "ヘッダーより後ろの列に挿入する",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("5"), seimei.WithHeader(), seimei.WithInsertedColumns()},
+			wantErr:   seimei.ErrColumnOutOfRecord,
+		},
+		{
+			name:      "ヘッダーより後ろの読みの列",
+			inputPath: "testdata/reading.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithReadingColumn("9"), seimei.WithHeader()},
+			wantErr:   seimei.ErrColumnOutOfRecord,
+		},
+		{
+			name:      "JSONは未対応",
+			inputPath: "testdata/multi_column.csv",
+			inputOpts: []seimei.Option{seimei.WithColumn("name"), seimei.WithHeader(), seimei.WithOutputFormat(seimei.JSONFormat)},
+			wantErr:   seimei.ErrColumnOutputFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			err := seimei.ParseFile(stdout, stderr, tt.inputPath, " ", tt.inputOpts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if stdout.Len() != 0 {
+					t.Errorf("written before the error: %q", stdout.String())
+				}
+				return
+			}
+
+			if diff := cmp.Diff(stdout.String(), tt.want); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if diff := cmp.Diff(stderr.String(), tt.wantErrOut); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
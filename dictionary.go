@@ -0,0 +1,124 @@
+package seimei
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+var (
+	ErrDictionaryEntryNotFound = errors.New("entry is not in the dictionary")
+	ErrEmptyDictionaryEntry    = errors.New("entry needs a last name")
+)
+
+// LoadNameDictionary loads a dictionary in the format of parser.DictionaryHeader.
+// The returned error lists every invalid line.
+func LoadNameDictionary(r io.Reader) (parser.NameDictionary, error) {
+	d, err := parser.ReadDictionaryCSV(r)
+	if err != nil {
+		return parser.NameDictionary{}, fmt.Errorf("invalid dictionary: %w", err)
+	}
+
+	return d, nil
+}
+
+// readDictionaryFile loads the dictionary of path. A missing file is an empty dictionary.
+func readDictionaryFile(path Path) (parser.NameDictionary, error) {
+	f, err := os.Open(string(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return parser.NewNameDictionary(), nil
+	}
+
+	if err != nil {
+		return parser.NameDictionary{}, fmt.Errorf("happen error load dictionary: %w", err)
+	}
+	defer f.Close()
+
+	return LoadNameDictionary(f)
+}
+
+func writeDictionaryFile(path Path, d parser.NameDictionary) error {
+	var b bytes.Buffer
+	if err := parser.WriteDictionaryCSV(&b, d); err != nil {
+		return fmt.Errorf("happen error write dictionary: %w", err)
+	}
+
+	//nolint:gosec
+	if err := os.WriteFile(string(path), b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("happen error write dictionary: %w", err)
+	}
+
+	return nil
+}
+
+// AddDictionaryEntry adds e to the dictionary file of path, creating the file when it does not exist.
+// A pinned full name already in the file is divided as e afterwards.
+func AddDictionaryEntry(path Path, e parser.DictionaryEntry) error {
+	if e.LastName == "" {
+		return ErrEmptyDictionaryEntry
+	}
+
+	d, err := readDictionaryFile(path)
+	if err != nil {
+		return err
+	}
+
+	return writeDictionaryFile(path, parser.NewNameDictionary(append(d.Entries(), e)...))
+}
+
+// RemoveDictionaryEntry removes e from the dictionary file of path.
+func RemoveDictionaryEntry(path Path, e parser.DictionaryEntry) error {
+	d, err := readDictionaryFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !d.Contains(e) {
+		return fmt.Errorf("%w: %s", ErrDictionaryEntryNotFound, parser.JoinName(e.LastName, e.FirstName))
+	}
+
+	es := d.Entries()
+	kept := es[:0]
+
+	for _, v := range es {
+		if v != e {
+			kept = append(kept, v)
+		}
+	}
+
+	return writeDictionaryFile(path, parser.NewNameDictionary(kept...))
+}
+
+// ListDictionary writes the entries of the dictionary file of path, a line per entry.
+// A pinned full name is written as "name", the last name and the first name joined by parseString,
+// and a known surname as "surname" and the surname, separated by a tab.
+func ListDictionary(out io.Writer, path Path, parseString ParseString) error {
+	f, err := os.Open(string(path))
+	if err != nil {
+		return fmt.Errorf("happen error load dictionary: %w", err)
+	}
+	defer f.Close()
+
+	d, err := LoadNameDictionary(f)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range d.Entries() {
+		kind, name := "name", string(e.LastName)+string(parseString)+string(e.FirstName)
+		if e.IsSurname() {
+			kind, name = "surname", string(e.LastName)
+		}
+
+		if _, err := fmt.Fprintf(out, "%s\t%s\n", kind, name); err != nil {
+			return fmt.Errorf("happen error write stdout: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package seimei_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDictionaryEntry(t *testing.T) {
+	t.Parallel()
+
+	path := seimei.Path(filepath.Join(t.TempDir(), "dict.csv"))
+
+	entries := []parser.DictionaryEntry{
+		{LastName: "竈門", FirstName: "炭治郎"},
+		{LastName: "勅使河原", FirstName: ""},
+		{LastName: "中曽根", FirstName: "康弘"},
+		// The division of a name already in the dictionary is replaced.
+		{LastName: "竈", FirstName: "門炭治郎"},
+	}
+
+	for _, e := range entries {
+		if err := seimei.AddDictionaryEntry(path, e); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+	}
+
+	if err := seimei.RemoveDictionaryEntry(path, parser.DictionaryEntry{LastName: "中曽根", FirstName: "康弘"}); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	err := seimei.RemoveDictionaryEntry(path, parser.DictionaryEntry{LastName: "竈門", FirstName: "炭治郎"})
+	if !errors.Is(err, seimei.ErrDictionaryEntryNotFound) {
+		t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, seimei.ErrDictionaryEntryNotFound)
+	}
+
+	got, err := os.ReadFile(string(path))
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := "last_name,first_name\n竈,門炭治郎\n勅使河原,\n"
+	if diff := cmp.Diff(string(got), want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+
+	out := &bytes.Buffer{}
+	if err := seimei.ListDictionary(out, path, "/"); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	if diff := cmp.Diff(out.String(), "name\t竈/門炭治郎\nsurname\t勅使河原\n"); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
@@ -0,0 +1,208 @@
+package seimei
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+const scoreBucketSize = 10
+
+// Accuracy is the number of correctly divided names out of the evaluated ones.
+type Accuracy struct {
+	Total   int
+	Correct int
+}
+
+func (a Accuracy) Rate() float64 {
+	if a.Total == 0 {
+		return 0
+	}
+
+	return float64(a.Correct) / float64(a.Total)
+}
+
+func (a *Accuracy) add(correct bool) {
+	a.Total++
+	if correct {
+		a.Correct++
+	}
+}
+
+// Miss is a name in the gold standard which was divided differently.
+type Miss struct {
+	Line int
+	Want parser.DividedName
+	// Got is zero when the name failed to be divided.
+	Got parser.DividedName
+	Err error
+}
+
+// EvalReport is the accuracy of division against gold-standard divided names.
+type EvalReport struct {
+	Overall Accuracy
+	// ByLength is keyed by the number of characters of the full name.
+	ByLength    map[int]*Accuracy
+	ByAlgorithm map[parser.Algorithm]*Accuracy
+	// ByScore is keyed by the score bucket, where bucket i covers scores in [i/10, (i+1)/10).
+	// A score of 1 falls in the last bucket.
+	ByScore map[int]*Accuracy
+	Misses  []Miss
+}
+
+func newEvalReport() EvalReport {
+	return EvalReport{
+		Overall:     Accuracy{},
+		ByLength:    make(map[int]*Accuracy),
+		ByAlgorithm: make(map[parser.Algorithm]*Accuracy),
+		ByScore:     make(map[int]*Accuracy),
+		Misses:      nil,
+	}
+}
+
+func (r *EvalReport) add(line int, want, got parser.DividedName, err error) {
+	correct := err == nil && got.LastName == want.LastName && got.FirstName == want.FirstName
+
+	r.Overall.add(correct)
+	accuracyOf(r.ByLength, parser.JoinName(want.LastName, want.FirstName).Length()).add(correct)
+
+	if err == nil {
+		accuracyOf(r.ByAlgorithm, got.Algorithm).add(correct)
+		accuracyOf(r.ByScore, scoreBucket(got.Score)).add(correct)
+	}
+
+	if !correct {
+		r.Misses = append(r.Misses, Miss{
+			Line: line,
+			Want: want,
+			Got:  got,
+			Err:  err,
+		})
+	}
+}
+
+func accuracyOf[K comparable](m map[K]*Accuracy, key K) *Accuracy {
+	a, ok := m[key]
+	if !ok {
+		a = &Accuracy{}
+		m[key] = a
+	}
+
+	return a
+}
+
+func scoreBucket(score float64) int {
+	b := int(score * scoreBucketSize)
+	if b >= scoreBucketSize {
+		return scoreBucketSize - 1
+	}
+
+	if b < 0 {
+		return 0
+	}
+
+	return b
+}
+
+// Evaluate divides the joined names in the file, in which each line is a last name and a first name
+// joined by parseString, and compares the results with the lines.
+func Evaluate(stderr io.Writer, path Path, parseString ParseString, opts ...Option) (EvalReport, error) {
+	cfg := newConfig(opts)
+	p := cfg.nameParser(parseString)
+
+	report := newEvalReport()
+
+	err := readDividedNames(stderr, path, parseString, func(line int, l parser.LastName, f parser.FirstName) error {
+		//nolint:exhaustivestruct
+		want := parser.DividedName{
+			LastName:  l,
+			FirstName: f,
+			Separator: parser.Separator(parseString),
+		}
+
+		got, err := p.Parse(parser.JoinName(l, f))
+		report.add(line, want, got, err)
+
+		return nil
+	})
+	if err != nil {
+		return EvalReport{}, err
+	}
+
+	return report, nil
+}
+
+// EvaluateFile writes the report of Evaluate as text.
+func EvaluateFile(out, stderr io.Writer, path Path, parseString ParseString, opts ...Option) error {
+	report, err := Evaluate(stderr, path, parseString, opts...)
+	if err != nil {
+		return err
+	}
+
+	if err := writeEvalReport(out, report); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func writeEvalReport(out io.Writer, r EvalReport) error {
+	if err := writeAccuracy(out, "overall", "all", r.Overall); err != nil {
+		return err
+	}
+
+	for _, l := range sortedKeys(r.ByLength) {
+		if err := writeAccuracy(out, "length", fmt.Sprint(l), *r.ByLength[l]); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range sortedKeys(r.ByAlgorithm) {
+		if err := writeAccuracy(out, "algorithm", string(a), *r.ByAlgorithm[a]); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range sortedKeys(r.ByScore) {
+		label := fmt.Sprintf("%.1f-%.1f", float64(b)/scoreBucketSize, float64(b+1)/scoreBucketSize)
+		if err := writeAccuracy(out, "score", label, *r.ByScore[b]); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range r.Misses {
+		got := m.Got.String()
+		if m.Err != nil {
+			got = m.Err.Error()
+		}
+
+		_, err := fmt.Fprintf(out, "miss\tline %d\twant=%s\tgot=%s\talgorithm=%s\tscore=%s\n",
+			m.Line, m.Want.String(), got, m.Got.Algorithm, formatScore(m.Got.Score))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeAccuracy(out io.Writer, group, key string, a Accuracy) error {
+	_, err := fmt.Fprintf(out, "%s\t%s\t%d/%d\t%s\n", group, key, a.Correct, a.Total, formatScore(a.Rate()))
+
+	return err
+}
+
+func sortedKeys[K int | parser.Algorithm](m map[K]*Accuracy) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
@@ -0,0 +1,115 @@
+package seimei_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	stderr := &bytes.Buffer{}
+
+	got, err := seimei.Evaluate(stderr, "testdata/gold.csv", " ")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := seimei.EvalReport{
+		Overall: seimei.Accuracy{Total: 5, Correct: 4},
+		ByLength: map[int]*seimei.Accuracy{
+			2: {Total: 1, Correct: 1},
+			4: {Total: 2, Correct: 2},
+			5: {Total: 2, Correct: 1},
+		},
+		ByAlgorithm: map[parser.Algorithm]*seimei.Accuracy{
+			parser.Rule:       {Total: 2, Correct: 2},
+			parser.Statistics: {Total: 3, Correct: 2},
+		},
+		ByScore: map[int]*seimei.Accuracy{
+			2: {Total: 1, Correct: 0},
+			3: {Total: 2, Correct: 2},
+			9: {Total: 2, Correct: 2},
+		},
+		Misses: []seimei.Miss{
+			{
+				Line: 4,
+				Want: parser.DividedName{
+					LastName:  "東海林",
+					FirstName: "太郎",
+					Separator: " ",
+				},
+				Got: parser.DividedName{
+					LastName:  "東海",
+					FirstName: "林太郎",
+					Separator: " ",
+					Score:     0.2648569404460349,
+					Algorithm: parser.Statistics,
+				},
+				Err: nil,
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("report mismatch (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(stderr.String(), "format error on line 6: [菅義偉]\n"); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+	if diff := cmp.Diff(got.Overall.Rate(), 0.8); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestEvaluate_Options(t *testing.T) {
+	t.Parallel()
+
+	d := parser.NewNameDictionary(parser.DictionaryEntry{LastName: "東海林", FirstName: "太郎"})
+
+	got, err := seimei.Evaluate(&bytes.Buffer{}, "testdata/gold.csv", " ", seimei.WithDictionary(d))
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	if diff := cmp.Diff(got.Overall, seimei.Accuracy{Total: 5, Correct: 5}); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+	want := map[parser.Algorithm]*seimei.Accuracy{
+		parser.Dictionary: {Total: 1, Correct: 1},
+		parser.Rule:       {Total: 2, Correct: 2},
+		parser.Statistics: {Total: 2, Correct: 2},
+	}
+	if diff := cmp.Diff(got.ByAlgorithm, want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestEvaluateFile(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	if err := seimei.EvaluateFile(stdout, stderr, "testdata/gold.csv", " "); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := `overall	all	4/5	0.8000
+length	2	1/1	1.0000
+length	4	2/2	1.0000
+length	5	1/2	0.5000
+algorithm	rule	2/2	1.0000
+algorithm	statistics	2/3	0.6667
+score	0.2-0.3	0/1	0.0000
+score	0.3-0.4	2/2	1.0000
+score	0.9-1.0	2/2	1.0000
+miss	line 4	want=東海林 太郎	got=東海 林太郎	algorithm=statistics	score=0.2649
+`
+	if diff := cmp.Diff(stdout.String(), want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
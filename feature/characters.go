@@ -1,7 +1,27 @@
 package feature
 
+import "unicode/utf8"
+
 type PartOfNameCharacters interface {
 	Length() int
 	Slice() []rune
 	IsLastName() bool
 }
+
+// namePiece is a piece of name given as a string, such as a line of the embedded kana or latin names.
+type namePiece struct {
+	s    string
+	last bool
+}
+
+func (p namePiece) Length() int {
+	return utf8.RuneCountInString(p.s)
+}
+
+func (p namePiece) Slice() []rune {
+	return []rune(p.s)
+}
+
+func (p namePiece) IsLastName() bool {
+	return p.last
+}
@@ -0,0 +1,22 @@
+package feature
+
+// Contribution is the value which one character adds to an order or length score.
+type Contribution struct {
+	Character Character
+	// Mask is the mask applied to the character's features, or nil when the character is not scored.
+	Mask  Features
+	Value float64
+	// IsDefault reports whether the character was missing from the table and DefaultKanjiFeature was used.
+	IsDefault bool
+}
+
+type Contributions []Contribution
+
+func (cs Contributions) Sum() float64 {
+	t := 0.0
+	for _, c := range cs {
+		t += c.Value
+	}
+
+	return t
+}
@@ -0,0 +1,211 @@
+package feature
+
+import "unicode/utf8"
+
+const orderMaskKinds = 4
+
+// orderMasks are the order masks returned by OrderMask, indexed by orderMaskKind.
+var orderMasks = [orderMaskKinds]Features{
+	{0, 0, 1, 1, 0, 0},
+	{0, 1, 1, 1, 0, 0},
+	{0, 0, 1, 1, 1, 0},
+	{0, 1, 1, 1, 1, 0},
+}
+
+// orderMaskKind selects the order mask of a character which is neither the first nor the last of the full name.
+func orderMaskKind(fullNameLength, charPosition int) int {
+	if fullNameLength == 3 {
+		return 0
+	}
+
+	if charPosition == 1 {
+		return 1
+	}
+
+	if charPosition == fullNameLength-2 {
+		return 2
+	}
+
+	return 3
+}
+
+type indexedKanjiFeature struct {
+	// order is the normalised order values of the character for each order mask.
+	order  [orderMaskKinds][OrderFeatureSize]float64
+	length [LengthFeatureSize]float64
+}
+
+// KanjiFeatureIndex is a KanjiFeatureManager prepared for scoring without allocation.
+// The order values are normalised for every mask in advance, and the length values are normalised
+// by summing the masked counts in place. Both give the same values as the calculators.
+type KanjiFeatureIndex struct {
+	positions map[rune]int
+	features  []indexedKanjiFeature
+}
+
+func NewKanjiFeatureIndex(m KanjiFeatureManager) KanjiFeatureIndex {
+	x := KanjiFeatureIndex{
+		positions: make(map[rune]int, len(m.KanjiFeatureMap)),
+		features:  make([]indexedKanjiFeature, 0, len(m.KanjiFeatureMap)),
+	}
+
+	for c, k := range m.KanjiFeatureMap {
+		r, size := utf8.DecodeRuneInString(string(c))
+		// Names are looked up by a single character, so the other keys are never found.
+		if size != len(c) || len(k.Order) != OrderFeatureSize || len(k.Length) != LengthFeatureSize {
+			continue
+		}
+
+		var f indexedKanjiFeature
+
+		for i, mask := range orderMasks {
+			for p := range f.order[i] {
+				// The sizes are checked above, so it never fails.
+				v, _ := k.GetOrderValue(OrderFeatureIndexPosition(p), mask)
+				f.order[i][p] = v
+			}
+		}
+
+		copy(f.length[:], k.Length)
+
+		x.positions[r] = len(x.features)
+		x.features = append(x.features, f)
+	}
+
+	if m.Variants != nil {
+		return x.withVariants(m, m.Variants)
+	}
+
+	return x
+}
+
+// withVariants returns the index which finds a variant at the position of its common form,
+// following canonical of m. The features are shared with x.
+func (x KanjiFeatureIndex) withVariants(m KanjiFeatureManager, t VariantTable) KanjiFeatureIndex {
+	positions := make(map[rune]int, len(x.positions)+len(t))
+
+	for r, i := range x.positions {
+		positions[r] = i
+	}
+
+	for v := range t {
+		r, size := utf8.DecodeRuneInString(string(v))
+		if size != len(v) {
+			continue
+		}
+
+		c := m.canonical(v)
+		if c == v {
+			continue
+		}
+
+		cr, _ := utf8.DecodeRuneInString(string(c))
+		if i, ok := x.positions[cr]; ok {
+			positions[r] = i
+		}
+	}
+
+	return KanjiFeatureIndex{
+		positions: positions,
+		features:  x.features,
+	}
+}
+
+func (x KanjiFeatureIndex) get(c rune) (*indexedKanjiFeature, bool) {
+	i, ok := x.positions[c]
+	if !ok {
+		return nil, false
+	}
+
+	return &x.features[i], true
+}
+
+// OrderValue returns the same value as KanjiFeature.GetOrderValue with the mask of OrderMask.
+// The first and the last character of the full name have no order value.
+func (x KanjiFeatureIndex) OrderValue(c rune, fullNameLength, charPosition int, p OrderFeatureIndexPosition) float64 {
+	if charPosition <= 0 || charPosition >= fullNameLength-1 {
+		return 0
+	}
+
+	f, ok := x.get(c)
+	if !ok {
+		return 0
+	}
+
+	return f.order[orderMaskKind(fullNameLength, charPosition)][p]
+}
+
+// LengthValue returns the same value as KanjiFeature.GetLengthValue with the mask of LengthMask.
+func (x KanjiFeatureIndex) LengthValue(c rune, fullNameLength, charPosition int, p LengthFeatureIndexPosition) float64 {
+	f, ok := x.get(c)
+	if !ok {
+		return 0
+	}
+
+	// The masked ranges of LengthMask, without the offset of the first name.
+	hi := fullNameLength - 1
+	if hi > LengthFeatureSize/2 {
+		hi = LengthFeatureSize / 2
+	}
+
+	lastLo := charPosition
+	firstLo := fullNameLength - charPosition - 1
+
+	// The counts are added in the order of the features, so that the total equals Features.Sum of the masked counts.
+	total := 0.0
+	for i := lastLo; i < hi; i++ {
+		total += f.length[i]
+	}
+
+	for i := firstLo; i < hi; i++ {
+		total += f.length[i+LengthFeatureSize/2]
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	lo, i := lastLo, int(p)
+	if i >= LengthFeatureSize/2 {
+		lo, i = firstLo, i-LengthFeatureSize/2
+	}
+
+	if i < lo || i >= hi {
+		return 0
+	}
+
+	return f.length[p] / total
+}
+
+// OrderPosition is SelectOrderFeaturePosition for the character at position in a piece of name of the length.
+func OrderPosition(isLastName bool, length, position int) OrderFeatureIndexPosition {
+	p := OrderEndFeatureIndex
+
+	switch position {
+	case 0:
+		p = OrderFirstFeatureIndex
+	case length - 1:
+	default:
+		p = OrderMiddleFeatureIndex
+	}
+
+	if isLastName {
+		return p
+	}
+
+	return p.MoveFirstNameIndex()
+}
+
+// LengthPosition is SelectLengthFeaturePosition for a piece of name of the length.
+func LengthPosition(isLastName bool, length int) LengthFeatureIndexPosition {
+	p := length
+	if p > LengthFeatureSize/2 {
+		p = LengthFeatureSize / 2
+	}
+
+	if isLastName {
+		return LengthFeatureIndexPosition(p - 1)
+	}
+
+	return LengthFeatureIndexPosition(p - 1).MoveFirstNameIndex()
+}
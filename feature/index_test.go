@@ -0,0 +1,59 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKanjiFeatureIndex(t *testing.T) {
+	t.Parallel()
+
+	m := seimei.InitKanjiFeatureManager()
+	sut := feature.NewKanjiFeatureIndex(m)
+
+	// 無 is not in the table.
+	for _, c := range "竈門炭治郎中曽根康弘乙無" {
+		k := m.Get(feature.Character(c))
+
+		for n := 2; n <= 10; n++ {
+			for pos := 0; pos < n; pos++ {
+				if pos != 0 && pos != n-1 {
+					mask, err := m.OrderMask(n, pos)
+					if err != nil {
+						t.Fatalf("happen error: %v", err)
+					}
+
+					for p := feature.OrderFeatureIndexPosition(0); p < feature.OrderFeatureSize; p++ {
+						want, err := k.GetOrderValue(p, mask)
+						if err != nil {
+							t.Fatalf("happen error: %v", err)
+						}
+
+						if diff := cmp.Diff(sut.OrderValue(c, n, pos, p), want); diff != "" {
+							t.Errorf("order value mismatch %c n=%d pos=%d p=%d (-got +want):\n%s", c, n, pos, p, diff)
+						}
+					}
+				}
+
+				mask, err := m.LengthMask(n, pos)
+				if err != nil {
+					t.Fatalf("happen error: %v", err)
+				}
+
+				for p := feature.LengthFeatureIndexPosition(0); p < feature.LengthFeatureSize; p++ {
+					want, err := k.GetLengthValue(p, mask)
+					if err != nil {
+						t.Fatalf("happen error: %v", err)
+					}
+
+					if diff := cmp.Diff(sut.LengthValue(c, n, pos, p), want); diff != "" {
+						t.Errorf("length value mismatch %c n=%d pos=%d p=%d (-got +want):\n%s", c, n, pos, p, diff)
+					}
+				}
+			}
+		}
+	}
+}
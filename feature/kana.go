@@ -0,0 +1,274 @@
+package feature
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// kanaStart and kanaEnd mark both ends of a piece of name, so that the first and the last kana are counted as well.
+	kanaStart = '^'
+	kanaEnd   = '$'
+	// kanaBigramWeight is the weight of the bigram probability interpolated with the unigram one.
+	kanaBigramWeight = 0.8
+	// lastPart and firstPart are the parts of name in the feature tables.
+	lastPart  = "last"
+	firstPart = "first"
+)
+
+var (
+	ErrNotKana              = errors.New("name must consist of kana")
+	ErrInvalidKanaHeader    = errors.New("header of kana feature table is invalid")
+	ErrInvalidKanaRecord    = errors.New("record of kana feature table must be part,prev,next,count")
+	ErrInvalidKanaPart      = errors.New("part must be last or first")
+	ErrInvalidKanaCharacter = errors.New("prev and next must be a single character")
+
+	// KanaFeatureHeader is the header of a kana feature table.
+	// Each record is the count of next following prev in the part of name, where ^ and $ are both ends of it.
+	KanaFeatureHeader = []string{"part", "prev", "next", "count"}
+)
+
+// IsKana reports whether r is hiragana, katakana or the prolonged sound mark.
+func IsKana(r rune) bool {
+	return (r >= 'ぁ' && r <= 'ゖ') || (r >= 'ァ' && r <= 'ヺ') || r == 'ー' || r == 'ゝ' || r == 'ゞ' || r == 'ヽ' || r == 'ヾ'
+}
+
+// IsKanaName reports whether s is not empty and consists of kana only.
+func IsKanaName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !IsKana(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FoldKana returns the hiragana of the katakana r, so that "ヤマダ" is counted as "やまだ".
+func FoldKana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+
+	return r
+}
+
+type kanaBigrams struct {
+	// counts is the count of each pair of kana, and contexts is the count of each preceding kana.
+	counts   map[[2]rune]float64
+	contexts map[rune]float64
+	// unigrams is the count of each following kana, and total is the sum of them.
+	unigrams map[rune]float64
+	total    float64
+}
+
+func newKanaBigrams() *kanaBigrams {
+	return &kanaBigrams{
+		counts:   make(map[[2]rune]float64),
+		contexts: make(map[rune]float64),
+		unigrams: make(map[rune]float64),
+		total:    0,
+	}
+}
+
+func (b *kanaBigrams) add(prev, next rune, n float64) {
+	b.counts[[2]rune{prev, next}] += n
+	b.contexts[prev] += n
+	b.unigrams[next] += n
+	b.total += n
+}
+
+// logProbability returns the log probability of next following prev.
+// The bigram probability is interpolated with the unigram one, which is smoothed by adding one.
+func (b kanaBigrams) logProbability(prev, next rune, vocabulary int) float64 {
+	unigram := (b.unigrams[next] + 1) / (b.total + float64(vocabulary))
+
+	p := (1 - kanaBigramWeight) * unigram
+	if c := b.contexts[prev]; c > 0 {
+		p += kanaBigramWeight * b.counts[[2]rune{prev, next}] / c
+	} else {
+		p += kanaBigramWeight * unigram
+	}
+
+	return math.Log(p)
+}
+
+// KanaModel is the bigram statistics of kana surnames and given names.
+// Katakana is folded into hiragana, so that both share the counts.
+type KanaModel struct {
+	last  *kanaBigrams
+	first *kanaBigrams
+	// symbols is every kana counted, which is the vocabulary of the smoothing.
+	symbols map[rune]struct{}
+}
+
+func NewKanaModel() KanaModel {
+	return KanaModel{
+		last:    newKanaBigrams(),
+		first:   newKanaBigrams(),
+		symbols: make(map[rune]struct{}),
+	}
+}
+
+func (m KanaModel) part(isLastName bool) *kanaBigrams {
+	if isLastName {
+		return m.last
+	}
+
+	return m.first
+}
+
+// Add counts the kana of the divided name.
+func (m KanaModel) Add(lastName, firstName PartOfNameCharacters) error {
+	if lastName.Length() == 0 || firstName.Length() == 0 {
+		return ErrEmptyPieceOfName
+	}
+
+	for _, pieceOfName := range []PartOfNameCharacters{lastName, firstName} {
+		if !IsKanaName(string(pieceOfName.Slice())) {
+			return fmt.Errorf("%w: %s", ErrNotKana, string(pieceOfName.Slice()))
+		}
+	}
+
+	for _, pieceOfName := range []PartOfNameCharacters{lastName, firstName} {
+		b := m.part(pieceOfName.IsLastName())
+		prev := rune(kanaStart)
+
+		for _, r := range pieceOfName.Slice() {
+			r = FoldKana(r)
+			m.add(b, prev, r, 1)
+			prev = r
+		}
+
+		m.add(b, prev, kanaEnd, 1)
+	}
+
+	return nil
+}
+
+func (m KanaModel) add(b *kanaBigrams, prev, next rune, n float64) {
+	b.add(prev, next, n)
+	m.symbols[next] = struct{}{}
+}
+
+// LogProbability returns the log probability of the kana s being a surname, or a given name when isLastName is false.
+func (m KanaModel) LogProbability(isLastName bool, s string) float64 {
+	b := m.part(isLastName)
+	vocabulary := len(m.symbols) + 1
+
+	lp := 0.0
+	prev := rune(kanaStart)
+
+	for _, r := range s {
+		r = FoldKana(r)
+		lp += b.logProbability(prev, r, vocabulary)
+		prev = r
+	}
+
+	return lp + b.logProbability(prev, kanaEnd, vocabulary)
+}
+
+// ReadKanaFeatureCSV reads a table in the format of KanaFeatureHeader with ReadTable.
+func ReadKanaFeatureCSV(r io.Reader) (KanaModel, error) {
+	m := NewKanaModel()
+
+	err := ReadTable(r, KanaFeatureHeader, ErrInvalidKanaHeader, func(_ int, record []string) error {
+		return m.addRecord(record)
+	})
+	if err != nil {
+		return KanaModel{}, err
+	}
+
+	return m, nil
+}
+
+func (m KanaModel) addRecord(record []string) error {
+	if len(record) != len(KanaFeatureHeader) {
+		return fmt.Errorf("%w: got %d fields", ErrInvalidKanaRecord, len(record))
+	}
+
+	var b *kanaBigrams
+
+	switch record[0] {
+	case lastPart:
+		b = m.last
+	case firstPart:
+		b = m.first
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidKanaPart, record[0])
+	}
+
+	prev, size := utf8.DecodeRuneInString(record[1])
+	if size == 0 || size != len(record[1]) {
+		return fmt.Errorf("%w: prev=%q", ErrInvalidKanaCharacter, record[1])
+	}
+
+	next, size := utf8.DecodeRuneInString(record[2])
+	if size == 0 || size != len(record[2]) {
+		return fmt.Errorf("%w: next=%q", ErrInvalidKanaCharacter, record[2])
+	}
+
+	n, err := strconv.ParseFloat(record[3], 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return fmt.Errorf("%w: count=%q", ErrInvalidCount, record[3])
+	}
+
+	if n < 0 {
+		return fmt.Errorf("%w: count=%q", ErrNegativeCount, record[3])
+	}
+
+	// The table may be written in katakana by hand, which is counted as hiragana in the same way as Add.
+	m.add(b, FoldKana(prev), FoldKana(next), n)
+
+	return nil
+}
+
+// WriteKanaFeatureCSV writes the table in the format of KanaFeatureHeader, ordered by part, prev and next.
+func WriteKanaFeatureCSV(w io.Writer, m KanaModel) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(KanaFeatureHeader); err != nil {
+		return fmt.Errorf("failed write header: %w", err)
+	}
+
+	for _, part := range []string{lastPart, firstPart} {
+		b := m.part(part == lastPart)
+
+		pairs := make([][2]rune, 0, len(b.counts))
+		for p := range b.counts {
+			pairs = append(pairs, p)
+		}
+
+		sort.Slice(pairs, func(i, j int) bool {
+			if pairs[i][0] != pairs[j][0] {
+				return pairs[i][0] < pairs[j][0]
+			}
+
+			return pairs[i][1] < pairs[j][1]
+		})
+
+		for _, p := range pairs {
+			record := []string{part, string(p[0]), string(p[1]), strconv.FormatFloat(b.counts[p], 'f', -1, 64)}
+			if err := cw.Write(record); err != nil {
+				return fmt.Errorf("failed write record: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed write table: %w", err)
+	}
+
+	return nil
+}
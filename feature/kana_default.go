@@ -0,0 +1,38 @@
+package feature
+
+import (
+	// Using embed.
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// kanaNames is the divided kana names of common surnames and given names, from which DefaultKanaModel is counted.
+//
+//go:embed assets/kana_names.txt
+var kanaNames string
+
+var (
+	defaultKanaModel     KanaModel
+	defaultKanaModelOnce sync.Once
+)
+
+// DefaultKanaModel returns the kana model counted from the embedded names.
+// The model is counted on the first call and shared by every caller afterwards, so it must not be modified.
+func DefaultKanaModel() KanaModel {
+	defaultKanaModelOnce.Do(func() {
+		m := NewKanaModel()
+
+		for _, line := range strings.Split(strings.TrimSpace(kanaNames), "\n") {
+			last, first, _ := strings.Cut(line, " ")
+			// since the embedded names are valid, it raise panic without returning an error.
+			if err := m.Add(namePiece{s: last, last: true}, namePiece{s: first, last: false}); err != nil {
+				panic(err)
+			}
+		}
+
+		defaultKanaModel = m
+	})
+
+	return defaultKanaModel
+}
@@ -0,0 +1,206 @@
+package feature_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsKanaName(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input string
+		want  bool
+	}
+
+	tests := []testdata{
+		{name: "ひらがな", input: "やまだはなこ", want: true},
+		{name: "カタカナと長音", input: "ルーシー", want: true},
+		{name: "漢字を含む", input: "山田はなこ", want: false},
+		{name: "空文字", input: "", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(feature.IsKanaName(tt.input), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKanaModel_Add(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   [2]string
+		wantErr error
+	}
+
+	tests := []testdata{
+		{name: "ひらがな", input: [2]string{"やまだ", "はなこ"}},
+		{name: "カタカナ", input: [2]string{"ヤマダ", "ハナコ"}},
+		{name: "漢字を含む", input: [2]string{"山田", "はなこ"}, wantErr: feature.ErrNotKana},
+		{name: "名前が空", input: [2]string{"やまだ", ""}, wantErr: feature.ErrEmptyPieceOfName},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := feature.NewKanaModel()
+			err := sut.Add(parser.LastName(tt.input[0]), parser.FirstName(tt.input[1]))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error mismatch: got=%v, want=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKanaModel_FoldKana(t *testing.T) {
+	t.Parallel()
+
+	hiragana := feature.NewKanaModel()
+	if err := hiragana.Add(parser.LastName("やまだ"), parser.FirstName("はなこ")); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	katakana := feature.NewKanaModel()
+	if err := katakana.Add(parser.LastName("ヤマダ"), parser.FirstName("ハナコ")); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	if diff := cmp.Diff(katakana.LogProbability(true, "やまだ"), hiragana.LogProbability(true, "ヤマダ")); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+
+	if hiragana.LogProbability(true, "やまだ") <= hiragana.LogProbability(false, "やまだ") {
+		t.Errorf("surname is not more likely as surname: %v", hiragana.LogProbability(true, "やまだ"))
+	}
+}
+
+func TestReadKanaFeatureCSV(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      string
+		wantErrMsg string
+	}
+
+	tests := []testdata{
+		{
+			name:  "正常",
+			input: "part,prev,next,count\nlast,^,や,1\nlast,や,$,1\nfirst,^,は,2\nfirst,は,$,2\n",
+		},
+		{
+			name:       "空のファイル",
+			input:      "",
+			wantErrMsg: "line 1: header of kana feature table is invalid: empty file",
+		},
+		{
+			name:       "ヘッダーが不正",
+			input:      "part,prev,next\nlast,^,や,1\n",
+			wantErrMsg: "line 1: header of kana feature table is invalid: [part prev next]",
+		},
+		{
+			name:       "不正なレコード",
+			input:      "part,prev,next,count\nmiddle,^,や,1\nlast,やま,$,1\nfirst,^,は,-1\nfirst,^\n",
+			wantErrMsg: "line 2: part must be last or first: \"middle\"\nline 3: prev and next must be a single character: prev=\"やま\"\nline 4: count must not be negative: count=\"-1\"\nline 5: record of kana feature table must be part,prev,next,count: got 2 fields",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := feature.ReadKanaFeatureCSV(strings.NewReader(tt.input))
+			if tt.wantErrMsg != "" {
+				if err == nil {
+					t.Fatal("happen no error")
+				}
+				if diff := cmp.Diff(err.Error(), tt.wantErrMsg); diff != "" {
+					t.Fatalf("failed to test on error. diff: %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			var b bytes.Buffer
+			if err := feature.WriteKanaFeatureCSV(&b, got); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(b.String(), tt.input); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteKanaFeatureCSV_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.DefaultKanaModel()
+
+	var b bytes.Buffer
+	if err := feature.WriteKanaFeatureCSV(&b, sut); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	got, err := feature.ReadKanaFeatureCSV(&b)
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	for _, s := range []string{"やまだ", "はなこ", "ぬ"} {
+		for _, isLastName := range []bool{true, false} {
+			if diff := cmp.Diff(got.LogProbability(isLastName, s), sut.LogProbability(isLastName, s)); diff != "" {
+				t.Errorf("value mismatch %s last=%v (-got +want):\n%s", s, isLastName, diff)
+			}
+		}
+	}
+}
+
+func TestReadKanaFeatureCSV_Katakana(t *testing.T) {
+	t.Parallel()
+
+	hiragana := "part,prev,next,count\nlast,^,や,1\nlast,ま,$,1\nlast,や,ま,1\nfirst,^,は,2\nfirst,は,$,2\n"
+	katakana := "part,prev,next,count\nlast,^,ヤ,1\nlast,ヤ,マ,1\nlast,ま,$,1\nfirst,^,ハ,2\nfirst,は,$,2\n"
+
+	want, err := feature.ReadKanaFeatureCSV(strings.NewReader(hiragana))
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	got, err := feature.ReadKanaFeatureCSV(strings.NewReader(katakana))
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	for _, s := range []string{"やま", "ヤマ", "は", "ハ"} {
+		for _, isLastName := range []bool{true, false} {
+			if diff := cmp.Diff(got.LogProbability(isLastName, s), want.LogProbability(isLastName, s)); diff != "" {
+				t.Errorf("value mismatch %s last=%v (-got +want):\n%s", s, isLastName, diff)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := feature.WriteKanaFeatureCSV(&b, got); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	if diff := cmp.Diff(b.String(), hiragana); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
@@ -40,10 +40,14 @@ type Character string
 
 type KanjiFeatureManager struct {
 	KanjiFeatureMap map[Character]KanjiFeature
+	// Index is KanjiFeatureMap prepared by NewKanjiFeatureIndex. When it is nil, NewStatisticsParser builds it.
+	Index *KanjiFeatureIndex
+	// Variants folds variant kanji for lookups. Set it with WithVariants to keep Index consistent.
+	Variants VariantTable
 }
 
 func (m KanjiFeatureManager) Get(c Character) KanjiFeature {
-	v, ok := m.KanjiFeatureMap[c]
+	v, ok := m.KanjiFeatureMap[m.canonical(c)]
 	if !ok {
 		return DefaultKanjiFeature()
 	}
@@ -51,6 +55,13 @@ func (m KanjiFeatureManager) Get(c Character) KanjiFeature {
 	return v
 }
 
+// Has reports whether the table has the character's features.
+func (m KanjiFeatureManager) Has(c Character) bool {
+	_, ok := m.KanjiFeatureMap[m.canonical(c)]
+
+	return ok
+}
+
 func (m KanjiFeatureManager) OrderMask(fullNameLength, charPosition int) (Features, error) {
 	if charPosition == 0 || charPosition == fullNameLength-1 {
 		return Features{}, ErrInvalidOrderMask
@@ -60,19 +71,7 @@ func (m KanjiFeatureManager) OrderMask(fullNameLength, charPosition int) (Featur
 		return Features{}, ErrOutRangeOrderMask
 	}
 
-	if fullNameLength == 3 {
-		return Features{0, 0, 1, 1, 0, 0}, nil
-	}
-
-	if charPosition == 1 {
-		return Features{0, 1, 1, 1, 0, 0}, nil
-	}
-
-	if charPosition == fullNameLength-2 {
-		return Features{0, 0, 1, 1, 1, 0}, nil
-	}
-
-	return Features{0, 1, 1, 1, 1, 0}, nil
+	return append(Features{}, orderMasks[orderMaskKind(fullNameLength, charPosition)]...), nil
 }
 
 func (m KanjiFeatureManager) LengthMask(fullNameLength, charPosition int) (Features, error) {
@@ -117,40 +116,11 @@ func (m KanjiFeatureManager) SelectOrderFeaturePosition(pieceOfName PartOfNameCh
 		return 0, ErrOutRangeFeatureIndex
 	}
 
-	if positionInPieceOfName == 0 {
-		if pieceOfName.IsLastName() {
-			return OrderFirstFeatureIndex, nil
-		}
-
-		return OrderFirstFeatureIndex.MoveFirstNameIndex(), nil
-	}
-
-	if positionInPieceOfName != pieceOfName.Length()-1 {
-		if pieceOfName.IsLastName() {
-			return OrderMiddleFeatureIndex, nil
-		}
-
-		return OrderMiddleFeatureIndex.MoveFirstNameIndex(), nil
-	}
-
-	if pieceOfName.IsLastName() {
-		return OrderEndFeatureIndex, nil
-	}
-
-	return OrderEndFeatureIndex.MoveFirstNameIndex(), nil
+	return OrderPosition(pieceOfName.IsLastName(), pieceOfName.Length(), positionInPieceOfName), nil
 }
 
 func (m KanjiFeatureManager) SelectLengthFeaturePosition(pieceOfName PartOfNameCharacters) (LengthFeatureIndexPosition, error) {
-	p := pieceOfName.Length()
-	if p > LengthFeatureSize/2 {
-		p = LengthFeatureSize / 2
-	}
-
-	if pieceOfName.IsLastName() {
-		return LengthFeatureIndexPosition(p - 1), nil
-	}
-
-	return LengthFeatureIndexPosition(p - 1).MoveFirstNameIndex(), nil
+	return LengthPosition(pieceOfName.IsLastName(), pieceOfName.Length()), nil
 }
 
 func DefaultKanjiFeature() KanjiFeature {
@@ -200,6 +170,16 @@ func (f Features) SoftMax() Features {
 	return e
 }
 
+// SoftMaxAt returns SoftMax()[i] without allocating the other values.
+func (f Features) SoftMaxAt(i int) float64 {
+	u := 0.0
+	for _, v := range f {
+		u += math.Exp(v)
+	}
+
+	return math.Exp(f[i]) / u
+}
+
 func defaultFeature(size int) Features {
 	return make(Features, size)
 }
@@ -0,0 +1,211 @@
+package feature
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrNotLatin           = errors.New("name must consist of latin letters")
+	ErrInvalidLatinHeader = errors.New("header of latin feature table is invalid")
+	ErrInvalidLatinRecord = errors.New("record of latin feature table must be part,name,count")
+	ErrInvalidLatinPart   = errors.New("part must be last or first")
+
+	// LatinFeatureHeader is the header of a latin feature table.
+	// Each record is the count of the folded name, such as "yamada", as the part of name.
+	LatinFeatureHeader = []string{"part", "name", "count"}
+)
+
+// IsLatin reports whether r is a letter of the latin script, such as a, Ō and é,
+// or an apostrophe or a hyphen within a name, such as "Jun'ichi".
+func IsLatin(r rune) bool {
+	return unicode.Is(unicode.Latin, r) || r == '\'' || r == '-' || r == '’'
+}
+
+// FoldLatin returns the name in lower case without macrons, apostrophes and hyphens,
+// so that "Jun'ichi" and "ŌNO" are counted as "junichi" and "ono".
+func FoldLatin(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\'', '-', '’':
+			return -1
+		case 'ā', 'Ā':
+			return 'a'
+		case 'ī', 'Ī':
+			return 'i'
+		case 'ū', 'Ū':
+			return 'u'
+		case 'ē', 'Ē':
+			return 'e'
+		case 'ō', 'Ō':
+			return 'o'
+		}
+
+		return unicode.ToLower(r)
+	}, s)
+}
+
+// LatinModel is the counts of romanized surnames and given names,
+// which tell the order of a name written in the latin script, such as "Taro Yamada".
+type LatinModel struct {
+	last  *latinCounts
+	first *latinCounts
+}
+
+type latinCounts struct {
+	counts map[string]float64
+	total  float64
+}
+
+func NewLatinModel() LatinModel {
+	return LatinModel{
+		last:  &latinCounts{counts: make(map[string]float64), total: 0},
+		first: &latinCounts{counts: make(map[string]float64), total: 0},
+	}
+}
+
+func (m LatinModel) part(isLastName bool) *latinCounts {
+	if isLastName {
+		return m.last
+	}
+
+	return m.first
+}
+
+// Add counts the divided name, such as "yamada" and "taro".
+func (m LatinModel) Add(lastName, firstName PartOfNameCharacters) error {
+	if lastName.Length() == 0 || firstName.Length() == 0 {
+		return ErrEmptyPieceOfName
+	}
+
+	for _, pieceOfName := range []PartOfNameCharacters{lastName, firstName} {
+		for _, r := range pieceOfName.Slice() {
+			if !IsLatin(r) {
+				return fmt.Errorf("%w: %s", ErrNotLatin, string(pieceOfName.Slice()))
+			}
+		}
+	}
+
+	m.add(true, string(lastName.Slice()), 1)
+	m.add(false, string(firstName.Slice()), 1)
+
+	return nil
+}
+
+func (m LatinModel) add(isLastName bool, name string, n float64) {
+	c := m.part(isLastName)
+	c.counts[FoldLatin(name)] += n
+	c.total += n
+}
+
+// LogProbability returns the log probability of the name being a surname, or a given name when isLastName is false.
+// The counts are smoothed by adding one, so that unknown names are equally likely as either.
+// A hyphenated name unknown as a whole, such as "Yamada-Sato", is scored by each of its names.
+func (m LatinModel) LogProbability(isLastName bool, name string) float64 {
+	folded := FoldLatin(name)
+
+	if names := strings.FieldsFunc(name, isHyphen); len(names) > 1 && !m.known(folded) {
+		p := 0.0
+		for _, v := range names {
+			p += m.LogProbability(isLastName, v)
+		}
+
+		return p
+	}
+
+	c := m.part(isLastName)
+	// The vocabulary is every name counted as either part, and an unknown one.
+	vocabulary := float64(len(m.last.counts) + len(m.first.counts) + 1)
+
+	return math.Log((c.counts[folded] + 1) / (c.total + vocabulary))
+}
+
+// known reports whether the folded name is counted as either part.
+func (m LatinModel) known(folded string) bool {
+	return m.last.counts[folded] > 0 || m.first.counts[folded] > 0
+}
+
+func isHyphen(r rune) bool {
+	return r == '-'
+}
+
+// ReadLatinFeatureCSV reads a table in the format of LatinFeatureHeader with ReadTable.
+func ReadLatinFeatureCSV(r io.Reader) (LatinModel, error) {
+	m := NewLatinModel()
+
+	err := ReadTable(r, LatinFeatureHeader, ErrInvalidLatinHeader, func(_ int, record []string) error {
+		return m.addRecord(record)
+	})
+	if err != nil {
+		return LatinModel{}, err
+	}
+
+	return m, nil
+}
+
+func (m LatinModel) addRecord(record []string) error {
+	if len(record) != len(LatinFeatureHeader) {
+		return fmt.Errorf("%w: got %d fields", ErrInvalidLatinRecord, len(record))
+	}
+
+	if record[0] != lastPart && record[0] != firstPart {
+		return fmt.Errorf("%w: %q", ErrInvalidLatinPart, record[0])
+	}
+
+	if record[1] == "" || strings.IndexFunc(record[1], func(r rune) bool { return !IsLatin(r) }) >= 0 {
+		return fmt.Errorf("%w: %q", ErrNotLatin, record[1])
+	}
+
+	n, err := strconv.ParseFloat(record[2], 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return fmt.Errorf("%w: count=%q", ErrInvalidCount, record[2])
+	}
+
+	if n < 0 {
+		return fmt.Errorf("%w: count=%q", ErrNegativeCount, record[2])
+	}
+
+	m.add(record[0] == lastPart, record[1], n)
+
+	return nil
+}
+
+// WriteLatinFeatureCSV writes the table in the format of LatinFeatureHeader, ordered by part and name.
+func WriteLatinFeatureCSV(w io.Writer, m LatinModel) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(LatinFeatureHeader); err != nil {
+		return fmt.Errorf("failed write header: %w", err)
+	}
+
+	for _, part := range []string{lastPart, firstPart} {
+		counts := m.part(part == lastPart).counts
+
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if err := cw.Write([]string{part, name, strconv.FormatFloat(counts[name], 'f', -1, 64)}); err != nil {
+				return fmt.Errorf("failed write record: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed write table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package feature
+
+import (
+	// Using embed.
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// latinNames is the embedded kana names romanized in modified Hepburn without long vowels, from which
+// DefaultLatinModel is counted.
+//
+//go:embed assets/latin_names.txt
+var latinNames string
+
+var (
+	defaultLatinModel     LatinModel
+	defaultLatinModelOnce sync.Once
+)
+
+// DefaultLatinModel returns the latin model counted from the embedded names.
+// The model is counted on the first call and shared by every caller afterwards, so it must not be modified.
+func DefaultLatinModel() LatinModel {
+	defaultLatinModelOnce.Do(func() {
+		m := NewLatinModel()
+
+		for _, line := range strings.Split(strings.TrimSpace(latinNames), "\n") {
+			last, first, _ := strings.Cut(line, " ")
+			// since the embedded names are valid, it raise panic without returning an error.
+			if err := m.Add(namePiece{s: last, last: true}, namePiece{s: first, last: false}); err != nil {
+				panic(err)
+			}
+		}
+
+		defaultLatinModel = m
+	})
+
+	return defaultLatinModel
+}
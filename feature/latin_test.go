@@ -0,0 +1,142 @@
+package feature_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFoldLatin(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input string
+		want  string
+	}
+
+	tests := []testdata{
+		{name: "大文字", input: "YAMADA", want: "yamada"},
+		{name: "長音記号", input: "Ōno", want: "ono"},
+		{name: "アポストロフィ", input: "Jun'ichi", want: "junichi"},
+		{name: "ハイフン", input: "Sato-Suzuki", want: "satosuzuki"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(feature.FoldLatin(tt.input), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLatinModel_Add(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   [2]string
+		wantErr error
+	}
+
+	tests := []testdata{
+		{name: "小文字", input: [2]string{"yamada", "taro"}},
+		{name: "長音記号とアポストロフィ", input: [2]string{"Ōno", "Jun'ichi"}},
+		{name: "かなを含む", input: [2]string{"やまだ", "taro"}, wantErr: feature.ErrNotLatin},
+		{name: "名前が空", input: [2]string{"yamada", ""}, wantErr: feature.ErrEmptyPieceOfName},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := feature.NewLatinModel()
+			err := sut.Add(parser.LastName(tt.input[0]), parser.FirstName(tt.input[1]))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error mismatch: got=%v, want=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLatinModel_LogProbability(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.DefaultLatinModel()
+
+	if sut.LogProbability(true, "YAMADA") <= sut.LogProbability(false, "Yamada") {
+		t.Errorf("surname is not more likely as surname: %v", sut.LogProbability(true, "YAMADA"))
+	}
+
+	if sut.LogProbability(false, "Taro") <= sut.LogProbability(true, "taro") {
+		t.Errorf("given name is not more likely as given name: %v", sut.LogProbability(false, "Taro"))
+	}
+}
+
+func TestReadLatinFeatureCSV(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      string
+		wantErrMsg string
+	}
+
+	tests := []testdata{
+		{
+			name:  "正常",
+			input: "part,name,count\nlast,suzuki,1\nlast,yamada,2\nfirst,taro,3\n",
+		},
+		{
+			name:       "空のファイル",
+			input:      "",
+			wantErrMsg: "line 1: header of latin feature table is invalid: empty file",
+		},
+		{
+			name:       "ヘッダーが不正",
+			input:      "part,name\nlast,yamada,1\n",
+			wantErrMsg: "line 1: header of latin feature table is invalid: [part name]",
+		},
+		{
+			name:       "不正なレコード",
+			input:      "part,name,count\nmiddle,yamada,1\nlast,山田,1\nfirst,taro,-1\nfirst,taro\n",
+			wantErrMsg: "line 2: part must be last or first: \"middle\"\nline 3: name must consist of latin letters: \"山田\"\nline 4: count must not be negative: count=\"-1\"\nline 5: record of latin feature table must be part,name,count: got 2 fields",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := feature.ReadLatinFeatureCSV(strings.NewReader(tt.input))
+			if tt.wantErrMsg != "" {
+				if err == nil {
+					t.Fatal("happen no error")
+				}
+				if diff := cmp.Diff(err.Error(), tt.wantErrMsg); diff != "" {
+					t.Fatalf("failed to test on error. diff: %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			var b bytes.Buffer
+			if err := feature.WriteLatinFeatureCSV(&b, got); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(b.String(), tt.input); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
@@ -37,3 +37,42 @@ func (fc KanjiLengthFeatureCalculator) Score(pieceOfName PartOfNameCharacters, f
 
 	return score, nil
 }
+
+// Explain returns the length value of each character in the piece of name.
+func (fc KanjiLengthFeatureCalculator) Explain(pieceOfName PartOfNameCharacters, fullNameLength int) (Contributions, error) {
+	offset := 0
+
+	if !pieceOfName.IsLastName() {
+		offset = fullNameLength - pieceOfName.Length()
+	}
+
+	cs := make(Contributions, 0, pieceOfName.Length())
+
+	for i, c := range pieceOfName.Slice() {
+		ci := i + offset
+
+		mask, err := fc.Manager.LengthMask(fullNameLength, ci)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		index, err := fc.Manager.SelectLengthFeaturePosition(pieceOfName)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		v, err := fc.Manager.Get(Character(c)).GetLengthValue(index, mask)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		cs = append(cs, Contribution{
+			Character: Character(c),
+			Mask:      mask,
+			Value:     v,
+			IsDefault: !fc.Manager.Has(Character(c)),
+		})
+	}
+
+	return cs, nil
+}
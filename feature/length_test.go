@@ -160,3 +160,31 @@ func stubKanjiManagerForLengthFeature() feature.KanjiFeatureManager {
 		},
 	}
 }
+
+func TestKanjiLengthFeatureCalculator_Explain(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.KanjiLengthFeatureCalculator{
+		Manager: stubKanjiManagerForLengthFeature(),
+	}
+	got, err := sut.Explain(parser.FirstName("冬太"), 4)
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+
+	want := feature.Contributions{
+		{Character: "冬", Mask: feature.Features{0, 0, 1, 0, 0, 1, 1, 0}, Value: 1.0 / 3, IsDefault: false},
+		{Character: "太", Mask: feature.Features{0, 0, 0, 0, 1, 1, 1, 0}, Value: 0, IsDefault: true},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("contributions mismatch (-got +want):\n%s", diff)
+	}
+
+	score, err := sut.Score(parser.FirstName("冬太"), 4)
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+	if score != got.Sum() {
+		t.Errorf("score is not the sum, got=(%v), want=(%v)", score, got.Sum())
+	}
+}
@@ -40,3 +40,53 @@ func (fc KanjiOrderFeatureCalculator) Score(pieceOfName PartOfNameCharacters, fu
 
 	return score, nil
 }
+
+// Explain returns the order value of each character in the piece of name.
+// The first and the last character of the full name have no order value, so their mask is nil.
+func (fc KanjiOrderFeatureCalculator) Explain(pieceOfName PartOfNameCharacters, fullNameLength int) (Contributions, error) {
+	offset := 0
+
+	if !pieceOfName.IsLastName() {
+		offset = fullNameLength - pieceOfName.Length()
+	}
+
+	cs := make(Contributions, 0, pieceOfName.Length())
+
+	for i, c := range pieceOfName.Slice() {
+		ci := i + offset
+		if ci == 0 || ci == fullNameLength-1 {
+			cs = append(cs, Contribution{
+				Character: Character(c),
+				Mask:      nil,
+				Value:     0,
+				IsDefault: !fc.Manager.Has(Character(c)),
+			})
+
+			continue
+		}
+
+		mask, err := fc.Manager.OrderMask(fullNameLength, ci)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		index, err := fc.Manager.SelectOrderFeaturePosition(pieceOfName, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		v, err := fc.Manager.Get(Character(c)).GetOrderValue(index, mask)
+		if err != nil {
+			return nil, fmt.Errorf("failed order score: %w", err)
+		}
+
+		cs = append(cs, Contribution{
+			Character: Character(c),
+			Mask:      mask,
+			Value:     v,
+			IsDefault: !fc.Manager.Has(Character(c)),
+		})
+	}
+
+	return cs, nil
+}
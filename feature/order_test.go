@@ -160,3 +160,35 @@ func stubKanjiManagerForOrderFeature() feature.KanjiFeatureManager {
 		},
 	}
 }
+
+func TestKanjiOrderFeatureCalculator_Explain(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.KanjiOrderFeatureCalculator{
+		Manager: stubKanjiManagerForOrderFeature(),
+	}
+	got, err := sut.Explain(parser.LastName("天ケ太"), 5)
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+
+	want := feature.Contributions{
+		{Character: "天", Mask: nil, Value: 0, IsDefault: false},
+		{Character: "ケ", Mask: feature.Features{0, 1, 1, 1, 0, 0}, Value: 1.0 / 3, IsDefault: false},
+		{Character: "太", Mask: feature.Features{0, 1, 1, 1, 1, 0}, Value: 0, IsDefault: true},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("contributions mismatch (-got +want):\n%s", diff)
+	}
+	if got.Sum() != 1.0/3 {
+		t.Errorf("sum is not expected, got=(%v), want=(%v)", got.Sum(), 1.0/3)
+	}
+
+	score, err := sut.Score(parser.LastName("天ケ太"), 5)
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+	if score != got.Sum() {
+		t.Errorf("score is not the sum, got=(%v), want=(%v)", score, got.Sum())
+	}
+}
@@ -0,0 +1,195 @@
+package feature
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+const kanjiFeatureRecordSize = CharacterFeatureSize + OrderFeatureSize + LengthFeatureSize
+
+var (
+	ErrInvalidTableHeader = errors.New("header of kanji feature table is invalid")
+	ErrInvalidRecordSize  = errors.New("record of kanji feature table must have 15 fields")
+	ErrInvalidCharacter   = errors.New("character must be a single character")
+	ErrDuplicateCharacter = errors.New("character is duplicated")
+	ErrInvalidCount       = errors.New("count must be a number")
+	ErrNegativeCount      = errors.New("count must not be negative")
+
+	// KanjiFeatureHeader is the header of a kanji feature table such as namedivider-python/assets/kanji.csv.
+	KanjiFeatureHeader = []string{
+		"kanji",
+		"oc_family_first", "oc_family_other", "oc_family_last",
+		"oc_given_first", "oc_given_other", "oc_given_last",
+		"lc_family_1", "lc_family_2", "lc_family_3", "lc_family_4",
+		"lc_given_1", "lc_given_2", "lc_given_3", "lc_given_4",
+	}
+)
+
+// ReadTable reads a CSV table whose first line is header, and passes each of the following records to add
+// with its line number. It validates every line and returns all the problems found, each prefixed by its line number.
+func ReadTable(r io.Reader, header []string, errHeader error, add func(line int, record []string) error) error {
+	cr := csv.NewReader(r)
+	// The width is checked per record by add to report it with the line number.
+	cr.FieldsPerRecord = -1
+
+	var errs []error
+
+	for i := 0; ; i++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			// An empty file has no header as well.
+			if i == 0 {
+				errs = append(errs, fmt.Errorf("line 1: %w: empty file", errHeader))
+			}
+
+			break
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if i == 0 {
+			if !isHeader(record, header) {
+				errs = append(errs, fmt.Errorf("line %d: %w: %v", line, errHeader, record))
+			}
+
+			continue
+		}
+
+		if err := add(line, record); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isHeader(record, header []string) bool {
+	if len(record) != len(header) {
+		return false
+	}
+
+	for i, h := range header {
+		if record[i] != h {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReadKanjiFeatureCSV reads a table in the format of KanjiFeatureHeader with ReadTable.
+// A character defined twice is reported with the line defining it first.
+func ReadKanjiFeatureCSV(r io.Reader) (KanjiFeatureManager, error) {
+	m := make(map[Character]KanjiFeature)
+	lines := make(map[Character]int)
+
+	err := ReadTable(r, KanjiFeatureHeader, ErrInvalidTableHeader, func(line int, record []string) error {
+		kf, err := parseKanjiFeatureRecord(record)
+		if err != nil {
+			return err
+		}
+
+		if l, ok := lines[kf.Character]; ok {
+			return fmt.Errorf("%w: %s is already defined on line %d", ErrDuplicateCharacter, kf.Character, l)
+		}
+
+		m[kf.Character] = kf
+		lines[kf.Character] = line
+
+		return nil
+	})
+	if err != nil {
+		return KanjiFeatureManager{}, err
+	}
+
+	manager := KanjiFeatureManager{
+		KanjiFeatureMap: m,
+	}
+	x := NewKanjiFeatureIndex(manager)
+	manager.Index = &x
+
+	return manager, nil
+}
+
+func parseKanjiFeatureRecord(record []string) (KanjiFeature, error) {
+	if len(record) != kanjiFeatureRecordSize {
+		return KanjiFeature{}, fmt.Errorf("%w: got %d fields", ErrInvalidRecordSize, len(record))
+	}
+
+	c := Character(record[0])
+	if utf8.RuneCountInString(string(c)) != 1 {
+		return KanjiFeature{}, fmt.Errorf("%w: %q", ErrInvalidCharacter, c)
+	}
+
+	counts := make([]float64, 0, OrderFeatureSize+LengthFeatureSize)
+
+	for i, v := range record[CharacterFeatureSize:] {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return KanjiFeature{}, fmt.Errorf("%w: %s=%q", ErrInvalidCount, KanjiFeatureHeader[i+CharacterFeatureSize], v)
+		}
+
+		if f < 0 {
+			return KanjiFeature{}, fmt.Errorf("%w: %s=%q", ErrNegativeCount, KanjiFeatureHeader[i+CharacterFeatureSize], v)
+		}
+
+		counts = append(counts, f)
+	}
+
+	return NewKanjiFeature(c, counts[:OrderFeatureSize:OrderFeatureSize], counts[OrderFeatureSize:])
+}
+
+// WriteKanjiFeatureCSV writes the table in the format of KanjiFeatureHeader, ordered by character.
+func WriteKanjiFeatureCSV(w io.Writer, m KanjiFeatureManager) error {
+	cs := make([]Character, 0, len(m.KanjiFeatureMap))
+	for c := range m.KanjiFeatureMap {
+		cs = append(cs, c)
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i] < cs[j]
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(KanjiFeatureHeader); err != nil {
+		return fmt.Errorf("failed write header: %w", err)
+	}
+
+	for _, c := range cs {
+		kf := m.KanjiFeatureMap[c]
+		record := make([]string, 0, len(KanjiFeatureHeader))
+		record = append(record, string(c))
+
+		for _, v := range kf.Order {
+			record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+
+		for _, v := range kf.Length {
+			record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed write record: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed write table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package feature_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/google/go-cmp/cmp"
+)
+
+const header = "kanji,oc_family_first,oc_family_other,oc_family_last,oc_given_first,oc_given_other,oc_given_last," +
+	"lc_family_1,lc_family_2,lc_family_3,lc_family_4,lc_given_1,lc_given_2,lc_given_3,lc_given_4\n"
+
+func TestReadTable(t *testing.T) {
+	t.Parallel()
+
+	errHeader := errors.New("header is invalid")
+	errRecord := errors.New("record is invalid")
+	// The record on line 3 continues to line 4 in the quotes.
+	input := "a,b\n1,2\n\"3\n4\",5\n6\n"
+
+	var got []string
+
+	err := feature.ReadTable(strings.NewReader(input), []string{"a", "b"}, errHeader, func(line int, record []string) error {
+		got = append(got, fmt.Sprintf("%d:%v", line, record))
+		if len(record) != 2 {
+			return errRecord
+		}
+
+		return nil
+	})
+
+	if diff := cmp.Diff(got, []string{"2:[1 2]", "3:[3\n4 5]", "5:[6]"}); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+	if !errors.Is(err, errRecord) {
+		t.Fatalf("error mismatch: got=%v, want=%v", err, errRecord)
+	}
+	if diff := cmp.Diff(err.Error(), "line 5: record is invalid"); diff != "" {
+		t.Errorf("failed to test on error. diff: %s", diff)
+	}
+
+	err = feature.ReadTable(strings.NewReader("a\n1\n"), []string{"a", "b"}, errHeader, func(int, []string) error {
+		return nil
+	})
+	if diff := cmp.Diff(err.Error(), "line 1: header is invalid: [a]"); diff != "" {
+		t.Errorf("failed to test on error. diff: %s", diff)
+	}
+
+	err = feature.ReadTable(strings.NewReader(""), []string{"a", "b"}, errHeader, func(int, []string) error {
+		return nil
+	})
+	if !errors.Is(err, errHeader) {
+		t.Fatalf("error mismatch: got=%v, want=%v", err, errHeader)
+	}
+}
+
+func TestReadKanjiFeatureCSV(t *testing.T) {
+	t.Parallel()
+
+	input := header + "乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n一,0,0,0,1,0,0,0,0,0,0,1,0,0,0\n"
+	got, err := feature.ReadKanjiFeatureCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+
+	want := feature.KanjiFeature{
+		Character: "乙",
+		Order:     feature.Features{1, 0, 0, 0, 0, 0},
+		Length:    feature.Features{1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	if diff := cmp.Diff(got.Get("乙"), want); diff != "" {
+		t.Errorf("feature value mismatch (-got +want):\n%s", diff)
+	}
+
+	// the written table is read as it is.
+	out := &bytes.Buffer{}
+	if err := feature.WriteKanjiFeatureCSV(out, got); err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+	if diff := cmp.Diff(out.String(), header+"一,0,0,0,1,0,0,0,0,0,0,1,0,0,0\n乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n"); diff != "" {
+		t.Errorf("csv mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestReadKanjiFeatureCSV_Invalid(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      string
+		wantErr    error
+		wantErrMsg string
+	}
+
+	tests := []testdata{
+		{
+			name:       "空のファイル",
+			input:      "",
+			wantErr:    feature.ErrInvalidTableHeader,
+			wantErrMsg: "line 1: header of kanji feature table is invalid: empty file",
+		},
+		{
+			name:       "ヘッダーが違う",
+			input:      "kanji,oc_family_first\n",
+			wantErr:    feature.ErrInvalidTableHeader,
+			wantErrMsg: "line 1: header of kanji feature table is invalid: [kanji oc_family_first]",
+		},
+		{
+			name:       "列数が足りない",
+			input:      header + "乙,1,0,0,0,0,0,1,0,0,0,0,0,0\n",
+			wantErr:    feature.ErrInvalidRecordSize,
+			wantErrMsg: "line 2: record of kanji feature table must have 15 fields: got 14 fields",
+		},
+		{
+			name:       "文字が複数",
+			input:      header + "乙一,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n",
+			wantErr:    feature.ErrInvalidCharacter,
+			wantErrMsg: `line 2: character must be a single character: "乙一"`,
+		},
+		{
+			name:       "数値でない",
+			input:      header + "乙,1,0,0,0,0,0,1,0,0,0,0,0,x,0\n",
+			wantErr:    feature.ErrInvalidCount,
+			wantErrMsg: `line 2: count must be a number: lc_given_3="x"`,
+		},
+		{
+			name:       "負の値",
+			input:      header + "乙,-1,0,0,0,0,0,1,0,0,0,0,0,0,0\n",
+			wantErr:    feature.ErrNegativeCount,
+			wantErrMsg: `line 2: count must not be negative: oc_family_first="-1"`,
+		},
+		{
+			name:       "文字が重複",
+			input:      header + "乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n一,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0\n",
+			wantErr:    feature.ErrDuplicateCharacter,
+			wantErrMsg: "line 4: character is duplicated: 乙 is already defined on line 2",
+		},
+		{
+			name:    "すべての行のエラーを返す",
+			input:   header + "乙,1\n一,-1,0,0,0,0,0,1,0,0,0,0,0,0,0\n",
+			wantErr: feature.ErrNegativeCount,
+			wantErrMsg: "line 2: record of kanji feature table must have 15 fields: got 2 fields\n" +
+				`line 3: count must not be negative: oc_family_first="-1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := feature.ReadKanjiFeatureCSV(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(err.Error(), tt.wantErrMsg); diff != "" {
+				t.Errorf("error message mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
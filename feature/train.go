@@ -0,0 +1,67 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrEmptyPieceOfName = errors.New("last name and first name must not be empty")
+
+// KanjiFeatureCounter builds a kanji feature table by counting characters of divided names.
+type KanjiFeatureCounter struct {
+	m KanjiFeatureManager
+}
+
+func NewKanjiFeatureCounter() KanjiFeatureCounter {
+	return KanjiFeatureCounter{
+		m: KanjiFeatureManager{
+			KanjiFeatureMap: make(map[Character]KanjiFeature),
+		},
+	}
+}
+
+// Add counts the order and the length of each character of the divided name.
+func (c KanjiFeatureCounter) Add(lastName, firstName PartOfNameCharacters) error {
+	if lastName.Length() == 0 || firstName.Length() == 0 {
+		return ErrEmptyPieceOfName
+	}
+
+	for _, pieceOfName := range []PartOfNameCharacters{lastName, firstName} {
+		lp, err := c.m.SelectLengthFeaturePosition(pieceOfName)
+		if err != nil {
+			return fmt.Errorf("failed count: %w", err)
+		}
+
+		for i, r := range pieceOfName.Slice() {
+			op, err := c.m.SelectOrderFeaturePosition(pieceOfName, i)
+			if err != nil {
+				return fmt.Errorf("failed count: %w", err)
+			}
+
+			kf := c.get(Character(r))
+			kf.Order[op]++
+			kf.Length[lp]++
+		}
+	}
+
+	return nil
+}
+
+func (c KanjiFeatureCounter) get(ch Character) KanjiFeature {
+	kf, ok := c.m.KanjiFeatureMap[ch]
+	if !ok {
+		kf = KanjiFeature{
+			Character: ch,
+			Order:     defaultFeature(OrderFeatureSize),
+			Length:    defaultFeature(LengthFeatureSize),
+		}
+		c.m.KanjiFeatureMap[ch] = kf
+	}
+
+	return kf
+}
+
+// Manager returns the counted table. It shares the counts, so later calls of Add are reflected.
+func (c KanjiFeatureCounter) Manager() KanjiFeatureManager {
+	return c.m
+}
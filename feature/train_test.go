@@ -0,0 +1,106 @@
+package feature_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKanjiFeatureCounter_Add(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name        string
+		inputKanji  feature.Character
+		wantFeature feature.KanjiFeature
+	}
+
+	sut := feature.NewKanjiFeatureCounter()
+	for _, n := range [][2]string{{"佐々木", "小次郎"}, {"乙", "一"}, {"乙", "々"}} {
+		if err := sut.Add(parser.LastName(n[0]), parser.FirstName(n[1])); err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+	}
+
+	tests := []testdata{
+		{
+			name:       "名字の途中と名前の最後",
+			inputKanji: "々",
+			wantFeature: feature.KanjiFeature{
+				Character: "々",
+				Order:     feature.Features{0, 1, 0, 1, 0, 0},
+				Length:    feature.Features{0, 0, 1, 0, 1, 0, 0, 0},
+			},
+		},
+		{
+			name:       "1文字の名字は先頭として数える",
+			inputKanji: "乙",
+			wantFeature: feature.KanjiFeature{
+				Character: "乙",
+				Order:     feature.Features{2, 0, 0, 0, 0, 0},
+				Length:    feature.Features{2, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{
+			name:       "名前の最後",
+			inputKanji: "郎",
+			wantFeature: feature.KanjiFeature{
+				Character: "郎",
+				Order:     feature.Features{0, 0, 0, 0, 0, 1},
+				Length:    feature.Features{0, 0, 0, 0, 0, 0, 1, 0},
+			},
+		},
+		{
+			name:        "出現しない文字はデフォルト",
+			inputKanji:  "無",
+			wantFeature: feature.DefaultKanjiFeature(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := sut.Manager().Get(tt.inputKanji)
+			if diff := cmp.Diff(got, tt.wantFeature); diff != "" {
+				t.Errorf("feature value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKanjiFeatureCounter_Add_Empty(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.NewKanjiFeatureCounter()
+	gotErr := sut.Add(parser.LastName("田中"), parser.FirstName(""))
+	if !errors.Is(gotErr, feature.ErrEmptyPieceOfName) {
+		t.Errorf("error is not expected, got error=(%v), want error=(%v)", gotErr, feature.ErrEmptyPieceOfName)
+	}
+}
+
+func TestWriteKanjiFeatureCSV(t *testing.T) {
+	t.Parallel()
+
+	sut := feature.NewKanjiFeatureCounter()
+	if err := sut.Add(parser.LastName("乙"), parser.FirstName("一")); err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+
+	got := &bytes.Buffer{}
+	if err := feature.WriteKanjiFeatureCSV(got, sut.Manager()); err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+
+	want := `kanji,oc_family_first,oc_family_other,oc_family_last,oc_given_first,oc_given_other,oc_given_last,lc_family_1,lc_family_2,lc_family_3,lc_family_4,lc_given_1,lc_given_2,lc_given_3,lc_given_4
+一,0,0,0,1,0,0,0,0,0,0,1,0,0,0
+乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0
+`
+	if diff := cmp.Diff(got.String(), want); diff != "" {
+		t.Errorf("csv mismatch (-got +want):\n%s", diff)
+	}
+}
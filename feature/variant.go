@@ -0,0 +1,73 @@
+package feature
+
+// VariantTable maps variant kanji (itaiji) to their common forms, such as 髙 to 高.
+type VariantTable map[Character]Character
+
+// DefaultVariantTable returns the variants often seen in family names and given names.
+func DefaultVariantTable() VariantTable {
+	return VariantTable{
+		"髙": "高",
+		"﨑": "崎",
+		"嵜": "崎",
+		"邊": "辺",
+		"邉": "辺",
+		"齋": "斎",
+		"齊": "斉",
+		"濱": "浜",
+		"濵": "浜",
+		"德": "徳",
+		"槗": "橋",
+		"冨": "富",
+		"𠮷": "吉",
+		"黑": "黒",
+		"國": "国",
+		"澤": "沢",
+		"廣": "広",
+		"櫻": "桜",
+		"惠": "恵",
+		"眞": "真",
+		"彌": "弥",
+		"瀨": "瀬",
+		"榮": "栄",
+		"條": "条",
+		"禮": "礼",
+		"壽": "寿",
+		"實": "実",
+		"將": "将",
+		"藏": "蔵",
+		"峯": "峰",
+		"桒": "桑",
+	}
+}
+
+// WithVariants returns the manager which looks up the features of a variant by its common form,
+// as long as the variant has no features of its own and the common form has. The characters of names are not changed.
+func (m KanjiFeatureManager) WithVariants(t VariantTable) KanjiFeatureManager {
+	m.Variants = t
+
+	if m.Index != nil {
+		x := m.Index.withVariants(m, t)
+		m.Index = &x
+	}
+
+	return m
+}
+
+// canonical returns the character whose features are used for c.
+// A variant with its own features, such as 髙 in the embedded table, keeps them.
+func (m KanjiFeatureManager) canonical(c Character) Character {
+	if _, ok := m.KanjiFeatureMap[c]; ok {
+		return c
+	}
+
+	v, ok := m.Variants[c]
+	if !ok {
+		return c
+	}
+
+	if _, ok := m.KanjiFeatureMap[v]; !ok {
+		return c
+	}
+
+	return v
+}
@@ -0,0 +1,115 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKanjiFeatureManager_WithVariants(t *testing.T) {
+	t.Parallel()
+
+	o := feature.Features{1, 2, 3, 4, 5, 6}
+	l := feature.Features{1, 2, 3, 4, 5, 6, 7, 8}
+	ownO := feature.Features{6, 5, 4, 3, 2, 1}
+	ownL := feature.Features{8, 7, 6, 5, 4, 3, 2, 1}
+	sut := feature.KanjiFeatureManager{
+		KanjiFeatureMap: map[feature.Character]feature.KanjiFeature{
+			"高": {Character: "高", Order: o, Length: l},
+			"﨑": {Character: "﨑", Order: ownO, Length: ownL},
+			"崎": {Character: "崎", Order: o, Length: l},
+		},
+	}.WithVariants(feature.VariantTable{"髙": "高", "舘": "館", "﨑": "崎"})
+
+	type testdata struct {
+		name    string
+		input   feature.Character
+		want    feature.KanjiFeature
+		wantHas bool
+	}
+	tests := []testdata{
+		{
+			name:    "異体字は標準の字で引く",
+			input:   "髙",
+			want:    feature.KanjiFeature{Character: "高", Order: o, Length: l},
+			wantHas: true,
+		},
+		{
+			name:    "標準の字が表にない異体字はそのまま引く",
+			input:   "舘",
+			want:    feature.DefaultKanjiFeature(),
+			wantHas: false,
+		},
+		{
+			name:    "表にある異体字は自身の素性で引く",
+			input:   "﨑",
+			want:    feature.KanjiFeature{Character: "﨑", Order: ownO, Length: ownL},
+			wantHas: true,
+		},
+		{
+			name:    "標準の字",
+			input:   "高",
+			want:    feature.KanjiFeature{Character: "高", Order: o, Length: l},
+			wantHas: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(sut.Get(tt.input), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+			if diff := cmp.Diff(sut.Has(tt.input), tt.wantHas); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKanjiFeatureIndex_WithVariants(t *testing.T) {
+	t.Parallel()
+
+	m := seimei.InitKanjiFeatureManager().WithVariants(feature.DefaultVariantTable())
+	sut := parser.NewStatisticsParser(m)
+
+	got, err := sut.Parse("槗本彩", " ")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	want, err := sut.Parse("橋本彩", " ")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	if diff := cmp.Diff(got.Score, want.Score); diff != "" {
+		t.Errorf("score mismatch (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(got.String(), "槗本 彩"); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+
+	// 髙 has its own row in the embedded table, which is kept instead of the row of 高.
+	got, err = sut.Parse("髙橋一生", " ")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	want, err = parser.NewStatisticsParser(seimei.InitKanjiFeatureManager()).Parse("髙橋一生", " ")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(m.Get("髙"), seimei.InitKanjiFeatureManager().Get("髙")); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+
+	// The embedded table is not changed by WithVariants.
+	if seimei.InitKanjiFeatureManager().Has("槗") {
+		t.Errorf("failed to test. embedded table is changed")
+	}
+}
@@ -0,0 +1,141 @@
+package seimei
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/glassmonkey/seimei/v2/namedivider"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServer implements namedivider.NameDividerServer with a NameParser shared by every call.
+type GRPCServer struct {
+	namedivider.UnimplementedNameDividerServer
+	parser        parser.NameParser
+	maxNameLength int
+}
+
+func NewGRPCServer(parseString ParseString, opts ...Option) *GRPCServer {
+	cfg := newConfig(opts)
+
+	//nolint:exhaustivestruct
+	return &GRPCServer{
+		parser:        cfg.nameParser(parseString),
+		maxNameLength: cfg.maxNameLength,
+	}
+}
+
+func (s *GRPCServer) Divide(_ context.Context, req *namedivider.DivideRequest) (*namedivider.DivideResponse, error) {
+	if err := validateRequestName(req.GetName(), s.maxNameLength); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	name, err := s.parser.Parse(parser.FullName(req.GetName()))
+	if err != nil {
+		var (
+			lc parser.ErrLowConfidence
+			da parser.ErrDisagreement
+		)
+		if errors.As(err, &lc) || errors.As(err, &da) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return newDivideResponse(req, name, nil), nil
+}
+
+// DivideStream answers each request in the order it is received.
+// An invalid or undividable name is answered with its error instead of ending the stream.
+func (s *GRPCServer) DivideStream(stream namedivider.NameDivider_DivideStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		var name parser.DividedName
+
+		err = validateRequestName(req.GetName(), s.maxNameLength)
+		if err == nil {
+			name, err = s.parser.Parse(parser.FullName(req.GetName()))
+		}
+
+		if err := stream.Send(newDivideResponse(req, name, err)); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+func newDivideResponse(req *namedivider.DivideRequest, name parser.DividedName, err error) *namedivider.DivideResponse {
+	if err != nil {
+		//nolint:exhaustivestruct
+		return &namedivider.DivideResponse{
+			Id:    req.GetId(),
+			Input: req.GetName(),
+			Error: err.Error(),
+		}
+	}
+
+	//nolint:exhaustivestruct
+	return &namedivider.DivideResponse{
+		Id:    req.GetId(),
+		Input: req.GetName(),
+		DividedName: &namedivider.DividedName{
+			LastName:   string(name.LastName),
+			FirstName:  string(name.FirstName),
+			MiddleName: string(name.MiddleName),
+			Separator:  string(name.Separator),
+			Score:      name.Score,
+			Algorithm:  string(name.Algorithm),
+		},
+		Normalized: string(name.Normalized),
+	}
+}
+
+// ServeGRPC listens on addr until ctx is done, and then stops the server gracefully.
+// The standard health service is registered, and reports NOT_SERVING while stopping.
+func ServeGRPC(ctx context.Context, stderr io.Writer, addr string, s *GRPCServer) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("happen error listen: %w", err)
+	}
+
+	gs := grpc.NewServer()
+	hs := health.NewServer()
+
+	namedivider.RegisterNameDividerServer(gs, s)
+	grpc_health_v1.RegisterHealthServer(gs, hs)
+
+	fmt.Fprintf(stderr, "listening on %s\n", l.Addr())
+
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- gs.Serve(l)
+	}()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("happen error serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	hs.Shutdown()
+	gs.GracefulStop()
+
+	return nil
+}
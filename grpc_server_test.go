@@ -0,0 +1,206 @@
+package seimei_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/namedivider"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func newNameDividerClient(t *testing.T, s *seimei.GRPCServer) namedivider.NameDividerClient {
+	t.Helper()
+
+	l := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	namedivider.RegisterNameDividerServer(gs, s)
+	go func() {
+		_ = gs.Serve(l)
+	}()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return namedivider.NewNameDividerClient(conn)
+}
+
+func TestGRPCServer_Divide(t *testing.T) {
+	t.Parallel()
+
+	client := newNameDividerClient(t, seimei.NewGRPCServer(" ", seimei.WithMaxNameLength(8), seimei.WithMinScore(0.3)))
+
+	type testdata struct {
+		name     string
+		input    *namedivider.DivideRequest
+		want     *namedivider.DivideResponse
+		wantCode codes.Code
+		wantMsg  string
+	}
+	tests := []testdata{
+		{
+			name:  "分割",
+			input: &namedivider.DivideRequest{Name: "田中太郎", Id: "1"},
+			want: &namedivider.DivideResponse{
+				Id:    "1",
+				Input: "田中太郎",
+				DividedName: &namedivider.DividedName{
+					LastName:  "田中",
+					FirstName: "太郎",
+					Separator: " ",
+					Score:     0.319858925466683,
+					Algorithm: "statistics",
+				},
+			},
+		},
+		{
+			name:  "外国人名のミドルネーム",
+			input: &namedivider.DivideRequest{Name: "エマ・リー・ロス", Id: "2"},
+			want: &namedivider.DivideResponse{
+				Id:    "2",
+				Input: "エマ・リー・ロス",
+				DividedName: &namedivider.DividedName{
+					LastName:   "ロス",
+					FirstName:  "エマ",
+					MiddleName: "リー",
+					Separator:  " ",
+					Score:      1,
+					Algorithm:  "foreign",
+				},
+			},
+		},
+		{
+			name:     "分割できない",
+			input:    &namedivider.DivideRequest{Name: "乙"},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "parse error: name length needs at least 2 chars",
+		},
+		{
+			name:     "名前が長すぎる",
+			input:    &namedivider.DivideRequest{Name: "寿限無寿限無五劫の擦り切れ"},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "name is too long: more than 8 characters",
+		},
+		{
+			name:     "確信度が低い",
+			input:    &namedivider.DivideRequest{Name: "竈門炭治郎"},
+			wantCode: codes.FailedPrecondition,
+			wantMsg:  "parse error: low confidence division: best=竈門 炭治郎, score=0.2473, margin=0.0276",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := client.Divide(context.Background(), tt.input)
+			if diff := cmp.Diff(status.Code(err), tt.wantCode); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if err != nil {
+				if diff := cmp.Diff(status.Convert(err).Message(), tt.wantMsg); diff != "" {
+					t.Errorf("failed to test. diff: %s", diff)
+				}
+				return
+			}
+			if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_DivideStream(t *testing.T) {
+	t.Parallel()
+
+	client := newNameDividerClient(t, seimei.NewGRPCServer("/"))
+
+	stream, err := client.DivideStream(context.Background())
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	inputs := []string{"田中太郎", "乙", "", "竈門炭治郎"}
+	for i, name := range inputs {
+		if err := stream.Send(&namedivider.DivideRequest{Name: name, Id: strings.Repeat("x", i)}); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if res.GetError() != "" {
+			got = append(got, res.GetId()+":"+res.GetError())
+			continue
+		}
+		got = append(got, res.GetId()+":"+res.GetDividedName().GetLastName()+res.GetDividedName().GetSeparator()+res.GetDividedName().GetFirstName())
+	}
+	want := []string{
+		":田中/太郎",
+		"x:parse error: name length needs at least 2 chars",
+		"xx:name is empty",
+		"xxx:竈門/炭治郎",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestServeGRPC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("コンテキストの終了で停止する", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stderr := &bytes.Buffer{}
+		errc := make(chan error, 1)
+		go func() {
+			errc <- seimei.ServeGRPC(ctx, stderr, "127.0.0.1:0", seimei.NewGRPCServer(" "))
+		}()
+		cancel()
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("failed to test. server did not stop")
+		}
+		if !strings.HasPrefix(stderr.String(), "listening on 127.0.0.1:") {
+			t.Errorf("failed to test. stderr: %s", stderr.String())
+		}
+	})
+}
@@ -0,0 +1,4 @@
+// Package namedivider is the gRPC API of seimei generated from namedivider.proto.
+package namedivider
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative namedivider.proto
@@ -0,0 +1,386 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: namedivider.proto
+
+package namedivider
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DivideRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// id is copied to the response to match them in DivideStream.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DivideRequest) Reset() {
+	*x = DivideRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_namedivider_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DivideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DivideRequest) ProtoMessage() {}
+
+func (x *DivideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_namedivider_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DivideRequest.ProtoReflect.Descriptor instead.
+func (*DivideRequest) Descriptor() ([]byte, []int) {
+	return file_namedivider_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DivideRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DivideRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DivideResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Input       string       `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	DividedName *DividedName `protobuf:"bytes,3,opt,name=divided_name,json=dividedName,proto3" json:"divided_name,omitempty"`
+	// error is empty when the name is divided.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// normalized is the name rewritten by the normalizers of the server, and is empty without them.
+	Normalized string `protobuf:"bytes,5,opt,name=normalized,proto3" json:"normalized,omitempty"`
+}
+
+func (x *DivideResponse) Reset() {
+	*x = DivideResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_namedivider_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DivideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DivideResponse) ProtoMessage() {}
+
+func (x *DivideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_namedivider_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DivideResponse.ProtoReflect.Descriptor instead.
+func (*DivideResponse) Descriptor() ([]byte, []int) {
+	return file_namedivider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DivideResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DivideResponse) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *DivideResponse) GetDividedName() *DividedName {
+	if x != nil {
+		return x.DividedName
+	}
+	return nil
+}
+
+func (x *DivideResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DivideResponse) GetNormalized() string {
+	if x != nil {
+		return x.Normalized
+	}
+	return ""
+}
+
+type DividedName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastName  string  `protobuf:"bytes,1,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	FirstName string  `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	Separator string  `protobuf:"bytes,3,opt,name=separator,proto3" json:"separator,omitempty"`
+	Score     float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Algorithm string  `protobuf:"bytes,5,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// middle_name is empty for the names without middle names, which are all the names but foreign ones.
+	MiddleName string `protobuf:"bytes,6,opt,name=middle_name,json=middleName,proto3" json:"middle_name,omitempty"`
+}
+
+func (x *DividedName) Reset() {
+	*x = DividedName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_namedivider_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DividedName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DividedName) ProtoMessage() {}
+
+func (x *DividedName) ProtoReflect() protoreflect.Message {
+	mi := &file_namedivider_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DividedName.ProtoReflect.Descriptor instead.
+func (*DividedName) Descriptor() ([]byte, []int) {
+	return file_namedivider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DividedName) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *DividedName) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *DividedName) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *DividedName) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DividedName) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *DividedName) GetMiddleName() string {
+	if x != nil {
+		return x.MiddleName
+	}
+	return ""
+}
+
+var File_namedivider_proto protoreflect.FileDescriptor
+
+var file_namedivider_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x69, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x65, 0x69, 0x6d, 0x65, 0x69, 0x2e, 0x76, 0x31, 0x22, 0x33,
+	0x0a, 0x0d, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xa7, 0x01, 0x0a, 0x0e, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x0c,
+	0x64, 0x69, 0x76, 0x69, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x69, 0x6d, 0x65, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x76, 0x69, 0x64, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x0b, 0x64, 0x69, 0x76, 0x69,
+	0x64, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x22, 0xbc, 0x01,
+	0x0a, 0x0b, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x70,
+	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0x95, 0x01, 0x0a,
+	0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x06,
+	0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x12, 0x18, 0x2e, 0x73, 0x65, 0x69, 0x6d, 0x65, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x73, 0x65, 0x69, 0x6d, 0x65, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x76,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x44,
+	0x69, 0x76, 0x69, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e, 0x73, 0x65,
+	0x69, 0x6d, 0x65, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x65, 0x69, 0x6d, 0x65, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x30, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x67, 0x6c, 0x61, 0x73, 0x73, 0x6d, 0x6f, 0x6e, 0x6b, 0x65, 0x79, 0x2f, 0x73,
+	0x65, 0x69, 0x6d, 0x65, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x69, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_namedivider_proto_rawDescOnce sync.Once
+	file_namedivider_proto_rawDescData = file_namedivider_proto_rawDesc
+)
+
+func file_namedivider_proto_rawDescGZIP() []byte {
+	file_namedivider_proto_rawDescOnce.Do(func() {
+		file_namedivider_proto_rawDescData = protoimpl.X.CompressGZIP(file_namedivider_proto_rawDescData)
+	})
+	return file_namedivider_proto_rawDescData
+}
+
+var file_namedivider_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_namedivider_proto_goTypes = []interface{}{
+	(*DivideRequest)(nil),  // 0: seimei.v1.DivideRequest
+	(*DivideResponse)(nil), // 1: seimei.v1.DivideResponse
+	(*DividedName)(nil),    // 2: seimei.v1.DividedName
+}
+var file_namedivider_proto_depIdxs = []int32{
+	2, // 0: seimei.v1.DivideResponse.divided_name:type_name -> seimei.v1.DividedName
+	0, // 1: seimei.v1.NameDivider.Divide:input_type -> seimei.v1.DivideRequest
+	0, // 2: seimei.v1.NameDivider.DivideStream:input_type -> seimei.v1.DivideRequest
+	1, // 3: seimei.v1.NameDivider.Divide:output_type -> seimei.v1.DivideResponse
+	1, // 4: seimei.v1.NameDivider.DivideStream:output_type -> seimei.v1.DivideResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_namedivider_proto_init() }
+func file_namedivider_proto_init() {
+	if File_namedivider_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_namedivider_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DivideRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_namedivider_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DivideResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_namedivider_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DividedName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_namedivider_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_namedivider_proto_goTypes,
+		DependencyIndexes: file_namedivider_proto_depIdxs,
+		MessageInfos:      file_namedivider_proto_msgTypes,
+	}.Build()
+	File_namedivider_proto = out.File
+	file_namedivider_proto_rawDesc = nil
+	file_namedivider_proto_goTypes = nil
+	file_namedivider_proto_depIdxs = nil
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: namedivider.proto
+
+package namedivider
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	NameDivider_Divide_FullMethodName       = "/seimei.v1.NameDivider/Divide"
+	NameDivider_DivideStream_FullMethodName = "/seimei.v1.NameDivider/DivideStream"
+)
+
+// NameDividerClient is the client API for NameDivider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NameDividerClient interface {
+	// Divide divides a single full name.
+	// An invalid or undividable name is answered with the status INVALID_ARGUMENT,
+	// and a division below the confidence thresholds with FAILED_PRECONDITION.
+	Divide(ctx context.Context, in *DivideRequest, opts ...grpc.CallOption) (*DivideResponse, error)
+	// DivideStream divides full names in the order they are sent.
+	// An undividable name is answered with its error, and the stream goes on.
+	DivideStream(ctx context.Context, opts ...grpc.CallOption) (NameDivider_DivideStreamClient, error)
+}
+
+type nameDividerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNameDividerClient(cc grpc.ClientConnInterface) NameDividerClient {
+	return &nameDividerClient{cc}
+}
+
+func (c *nameDividerClient) Divide(ctx context.Context, in *DivideRequest, opts ...grpc.CallOption) (*DivideResponse, error) {
+	out := new(DivideResponse)
+	err := c.cc.Invoke(ctx, NameDivider_Divide_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nameDividerClient) DivideStream(ctx context.Context, opts ...grpc.CallOption) (NameDivider_DivideStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &NameDivider_ServiceDesc.Streams[0], NameDivider_DivideStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nameDividerDivideStreamClient{stream}
+	return x, nil
+}
+
+type NameDivider_DivideStreamClient interface {
+	Send(*DivideRequest) error
+	Recv() (*DivideResponse, error)
+	grpc.ClientStream
+}
+
+type nameDividerDivideStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *nameDividerDivideStreamClient) Send(m *DivideRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nameDividerDivideStreamClient) Recv() (*DivideResponse, error) {
+	m := new(DivideResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NameDividerServer is the server API for NameDivider service.
+// All implementations must embed UnimplementedNameDividerServer
+// for forward compatibility
+type NameDividerServer interface {
+	// Divide divides a single full name.
+	// An invalid or undividable name is answered with the status INVALID_ARGUMENT,
+	// and a division below the confidence thresholds with FAILED_PRECONDITION.
+	Divide(context.Context, *DivideRequest) (*DivideResponse, error)
+	// DivideStream divides full names in the order they are sent.
+	// An undividable name is answered with its error, and the stream goes on.
+	DivideStream(NameDivider_DivideStreamServer) error
+	mustEmbedUnimplementedNameDividerServer()
+}
+
+// UnimplementedNameDividerServer must be embedded to have forward compatible implementations.
+type UnimplementedNameDividerServer struct {
+}
+
+func (UnimplementedNameDividerServer) Divide(context.Context, *DivideRequest) (*DivideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Divide not implemented")
+}
+func (UnimplementedNameDividerServer) DivideStream(NameDivider_DivideStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DivideStream not implemented")
+}
+func (UnimplementedNameDividerServer) mustEmbedUnimplementedNameDividerServer() {}
+
+// UnsafeNameDividerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NameDividerServer will
+// result in compilation errors.
+type UnsafeNameDividerServer interface {
+	mustEmbedUnimplementedNameDividerServer()
+}
+
+func RegisterNameDividerServer(s grpc.ServiceRegistrar, srv NameDividerServer) {
+	s.RegisterService(&NameDivider_ServiceDesc, srv)
+}
+
+func _NameDivider_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DivideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NameDividerServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NameDivider_Divide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NameDividerServer).Divide(ctx, req.(*DivideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NameDivider_DivideStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NameDividerServer).DivideStream(&nameDividerDivideStreamServer{stream})
+}
+
+type NameDivider_DivideStreamServer interface {
+	Send(*DivideResponse) error
+	Recv() (*DivideRequest, error)
+	grpc.ServerStream
+}
+
+type nameDividerDivideStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *nameDividerDivideStreamServer) Send(m *DivideResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *nameDividerDivideStreamServer) Recv() (*DivideRequest, error) {
+	m := new(DivideRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NameDivider_ServiceDesc is the grpc.ServiceDesc for NameDivider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NameDivider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "seimei.v1.NameDivider",
+	HandlerType: (*NameDividerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Divide",
+			Handler:    _NameDivider_Divide_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DivideStream",
+			Handler:       _NameDivider_DivideStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "namedivider.proto",
+}
@@ -0,0 +1,51 @@
+package seimei
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+var ErrUnknownNormalizer = errors.New("normalizer must be one of nfkc, width and space")
+
+// Normalizers are the normalizers selectable by name, such as the flag --normalize.
+var Normalizers = map[string]parser.Normalizer{
+	"nfkc":  parser.NFKCNormalizer{},
+	"width": parser.WidthNormalizer{},
+	"space": parser.SpaceNormalizer{},
+}
+
+// ParseNormalizers selects the normalizers by the names in the given order.
+func ParseNormalizers(names []string) ([]parser.Normalizer, error) {
+	ns := make([]parser.Normalizer, 0, len(names))
+
+	for _, name := range names {
+		n, ok := Normalizers[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownNormalizer, name)
+		}
+
+		ns = append(ns, n)
+	}
+
+	return ns, nil
+}
+
+// removedDelimiter returns a delimiter which ns remove or rewrite to another character, such as U+3000 removed by
+// parser.SpaceNormalizer, since the names are normalized before parser.GivenParser looks for the delimiters.
+func removedDelimiter(ns []parser.Normalizer, ds []rune) (rune, bool) {
+	for _, d := range ds {
+		s := parser.FullName(d)
+		for _, n := range ns {
+			s = n.Normalize(s)
+		}
+
+		if !strings.ContainsAny(string(s), string(ds)) {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,302 @@
+package seimei
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/glassmonkey/seimei/v2/romanize"
+)
+
+// Option changes how ParseName and ParseFile divide names.
+type Option func(*config)
+
+type config struct {
+	minScore  float64
+	minMargin float64
+	reject    io.Writer
+	manager   *feature.KanjiFeatureManager
+	format    OutputFormat
+	column    string
+	reading   string
+	header    bool
+	insert    bool
+	workers   int
+	cacheSize int
+	// maxNameLength and maxBatchSize limit the requests of Server.
+	maxNameLength int
+	maxBatchSize  int
+	normalizers   []parser.Normalizer
+	variants      feature.VariantTable
+	dictionary    *parser.NameDictionary
+	algorithms    []parser.Algorithm
+	kanaModel     *feature.KanaModel
+	latinModel    *feature.LatinModel
+	romanizer     *romanize.Romanizer
+	// given divides at the delimiters already in the names, and checkGiven reports the disagreements with the model.
+	given      bool
+	checkGiven bool
+	delimiters []rune
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		format:        TextFormat,
+		workers:       1,
+		maxNameLength: defaultMaxNameLength,
+		maxBatchSize:  defaultMaxBatchSize,
+	}
+	for _, o := range opts {
+		o(&c)
+	}
+
+	return c
+}
+
+func (c config) apply(p parser.NameParser) parser.NameParser {
+	p.MinScore = c.minScore
+	p.MinMargin = c.minMargin
+	p.Normalizers = c.normalizers
+
+	if c.dictionary != nil {
+		p = p.WithDictionary(*c.dictionary)
+	}
+
+	if c.given {
+		return p.WithGiven(c.checkGiven, c.delimiters...)
+	}
+
+	return p
+}
+
+// nameParser returns the name parser configured by c.
+func (c config) nameParser(parseString ParseString) parser.NameParser {
+	return c.apply(InitNameParser(parseString, c.kanjiFeatureManager(), c.parserOptions()...))
+}
+
+func (c config) parserOptions() []parser.NameParserOption {
+	var opts []parser.NameParserOption
+
+	if c.algorithms != nil {
+		opts = append(opts, parser.WithAlgorithms(c.algorithms...))
+	}
+
+	if c.kanaModel != nil {
+		opts = append(opts, parser.WithKanaModel(*c.kanaModel))
+	}
+
+	if c.latinModel != nil {
+		opts = append(opts, parser.WithLatinModel(*c.latinModel))
+	}
+
+	return opts
+}
+
+// romanize returns the romanisation of reading, or of name when reading is zero, or empty without the romanizer.
+func (c config) romanize(name, reading parser.DividedName) (string, error) {
+	if c.romanizer == nil {
+		return "", nil
+	}
+
+	if !reading.IsZero() {
+		name = reading
+	}
+
+	s, err := c.romanizer.RomanizeName(name)
+	if err != nil {
+		return "", fmt.Errorf("romanize error: %w", err)
+	}
+
+	return s, nil
+}
+
+func (c config) kanjiFeatureManager() feature.KanjiFeatureManager {
+	m := InitKanjiFeatureManager()
+	if c.manager != nil {
+		m = *c.manager
+	}
+
+	if c.variants != nil {
+		return m.WithVariants(c.variants)
+	}
+
+	return m
+}
+
+// WithKanjiFeatureManager divides names with m instead of the embedded kanji feature table.
+func WithKanjiFeatureManager(m feature.KanjiFeatureManager) Option {
+	return func(c *config) {
+		c.manager = &m
+	}
+}
+
+// WithMinScore rejects divisions whose score is lower than v.
+func WithMinScore(v float64) Option {
+	return func(c *config) {
+		c.minScore = v
+	}
+}
+
+// WithMinMargin rejects divisions whose score is not higher than the runner-up's by at least v.
+func WithMinMargin(v float64) Option {
+	return func(c *config) {
+		c.minMargin = v
+	}
+}
+
+// WithRejectWriter writes rows rejected for low confidence to w as CSV instead of the error output.
+// The columns are the input, the best division, its score and its margin.
+func WithRejectWriter(w io.Writer) Option {
+	return func(c *config) {
+		c.reject = w
+	}
+}
+
+// WithOutputFormat writes the divided names in f. The default is TextFormat.
+func WithOutputFormat(f OutputFormat) Option {
+	return func(c *config) {
+		c.format = f
+	}
+}
+
+// WithColumn divides the column of each record, given by a header name or a zero-based index,
+// and writes each record with the last name and the first name added as CSV.
+func WithColumn(column string) Option {
+	return func(c *config) {
+		c.column = column
+	}
+}
+
+// WithReadingColumn divides the kana reading in the column, given by a header name or a zero-based index,
+// together with the name by parser.NameParser.ParsePair, and adds the divided reading after the divided name.
+// The name is taken from the first column unless WithColumn is given. Pairs whose reading does not fit the name
+// are reported as parser.ErrUnreconciled. The cache of WithCacheSize is not used for pairs.
+// The JSON formats write the divided reading as the fields of Result instead of adding the columns to the record.
+func WithReadingColumn(column string) Option {
+	return func(c *config) {
+		c.reading = column
+	}
+}
+
+// WithHeader treats the first record of the file as the header.
+func WithHeader() Option {
+	return func(c *config) {
+		c.header = true
+	}
+}
+
+// WithInsertedColumns places the last name and the first name right after the name column
+// instead of the end of the record.
+func WithInsertedColumns() Option {
+	return func(c *config) {
+		c.insert = true
+	}
+}
+
+// WithWorkers divides names of a file with n goroutines. Values below 1 mean runtime.NumCPU().
+// The results are written in the order of the file regardless of n.
+func WithWorkers(n int) Option {
+	return func(c *config) {
+		c.workers = n
+	}
+}
+
+// WithCacheSize keeps the results of the n most recently divided names of a file and reuses them for
+// repeated names. The numbers of cache hits and misses are written to stderr at the end.
+func WithCacheSize(n int) Option {
+	return func(c *config) {
+		c.cacheSize = n
+	}
+}
+
+// WithMaxNameLength makes Server reject names longer than n characters.
+func WithMaxNameLength(n int) Option {
+	return func(c *config) {
+		c.maxNameLength = n
+	}
+}
+
+// WithMaxBatchSize makes Server reject batches of more than n names.
+func WithMaxBatchSize(n int) Option {
+	return func(c *config) {
+		c.maxBatchSize = n
+	}
+}
+
+// WithNormalizers rewrites names with ns in order before dividing them.
+// The structured output formats have the rewritten name as normalized.
+func WithNormalizers(ns ...parser.Normalizer) Option {
+	return func(c *config) {
+		c.normalizers = ns
+	}
+}
+
+// WithVariants looks up the features of variant kanji, such as 髙, by their common forms in t, such as 高.
+// The divided names keep the original characters.
+func WithVariants(t feature.VariantTable) Option {
+	return func(c *config) {
+		c.variants = t
+	}
+}
+
+// WithGivenDelimiters divides names at a delimiter already in them, such as "竈門　炭治郎",
+// instead of estimating the division. Without ds, parser.DefaultDelimiters are used.
+// The delimiters are looked for after WithNormalizers, so that parser.SpaceNormalizer leaves no space to divide at.
+func WithGivenDelimiters(ds ...rune) Option {
+	return func(c *config) {
+		c.given = true
+		c.delimiters = ds
+	}
+}
+
+// WithGivenCheck reports names whose delimiter disagrees with the estimated division as
+// parser.ErrDisagreement instead of trusting the delimiter. It implies WithGivenDelimiters.
+func WithGivenCheck() Option {
+	return func(c *config) {
+		c.given = true
+		c.checkGiven = true
+	}
+}
+
+// WithDictionary divides the full names pinned in d and the names starting with its known surnames
+// as the dictionary says, before estimating the division.
+func WithDictionary(d parser.NameDictionary) Option {
+	return func(c *config) {
+		c.dictionary = &d
+	}
+}
+
+// WithAlgorithms divides names with the parsers registered for as in order, such as parser.Statistics alone,
+// instead of the default chain of parser.Latin, parser.Foreign, parser.Rule and parser.Statistics, which starts with
+// parser.Kana given WithKanaModel. The dictionary of WithDictionary is tried first in either case.
+// Building the parser panics on an algorithm which is not registered, so use ParseAlgorithms to validate the names given by users.
+func WithAlgorithms(as ...parser.Algorithm) Option {
+	return func(c *config) {
+		c.algorithms = as
+	}
+}
+
+// WithKanaModel divides kana only names, such as "やまだはなこ", with m instead of the embedded kana names.
+// The kana parser is put at the head of the default chain, which leaves it out otherwise.
+func WithKanaModel(m feature.KanaModel) Option {
+	return func(c *config) {
+		c.kanaModel = &m
+	}
+}
+
+// WithLatinModel orders the names in the latin script, such as "Taro Yamada", with m instead of the embedded romanized names.
+func WithLatinModel(m feature.LatinModel) Option {
+	return func(c *config) {
+		c.latinModel = &m
+	}
+}
+
+// WithRomanizer writes the divided names in the Latin alphabet with r, such as "YAMADA Hanako".
+// The reading of WithReadingColumn is romanized if given, and otherwise the names must be kana.
+// The text format writes the romanisation instead of the divided name, and the other formats add it as romanized.
+func WithRomanizer(r romanize.Romanizer) Option {
+	return func(c *config) {
+		c.romanizer = &r
+	}
+}
@@ -0,0 +1,306 @@
+package seimei
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+type OutputFormat string
+
+const (
+	TextFormat  OutputFormat = "text"
+	JSONFormat  OutputFormat = "json"
+	JSONLFormat OutputFormat = "jsonl"
+	CSVFormat   OutputFormat = "csv"
+	TSVFormat   OutputFormat = "tsv"
+)
+
+var ErrUnknownOutputFormat = errors.New("output format must be one of text, json, jsonl, csv and tsv")
+
+// OutputFormats lists the supported formats in the order shown to users.
+var OutputFormats = []OutputFormat{TextFormat, JSONFormat, JSONLFormat, CSVFormat, TSVFormat}
+
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	for _, f := range OutputFormats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownOutputFormat, s)
+}
+
+// Result is a row of the structured output formats.
+type Result struct {
+	Input     string `json:"input"`
+	LastName  string `json:"last_name"`
+	FirstName string `json:"first_name"`
+	// MiddleName is omitted for the names without middle names.
+	MiddleName string  `json:"middle_name,omitempty"`
+	Score      float64 `json:"score"`
+	Algorithm  string  `json:"algorithm"`
+	// Error is empty when the name is divided.
+	Error string `json:"error"`
+	// Normalized is the input rewritten by the normalizers, and is omitted without them.
+	Normalized string `json:"normalized,omitempty"`
+	// Romanized is the divided name in the Latin alphabet, and is omitted without the romanizer.
+	Romanized string `json:"romanized,omitempty"`
+	// LastNameReading and FirstNameReading are the divided kana reading of WithReadingColumn, and are omitted without it.
+	LastNameReading  string `json:"last_name_reading,omitempty"`
+	FirstNameReading string `json:"first_name_reading,omitempty"`
+}
+
+var (
+	resultHeader    = []string{"input", "last_name", "first_name", "middle_name", "score", "algorithm", "error"}
+	romanizedHeader = "romanized"
+)
+
+func NewResult(input string, name parser.DividedName, err error) Result {
+	if err != nil {
+		//nolint:exhaustivestruct
+		return Result{
+			Input: input,
+			Error: err.Error(),
+		}
+	}
+
+	return Result{
+		Input:      input,
+		LastName:   string(name.LastName),
+		FirstName:  string(name.FirstName),
+		MiddleName: string(name.MiddleName),
+		Score:      name.Score,
+		Algorithm:  string(name.Algorithm),
+		Error:      "",
+		Normalized: string(name.Normalized),
+	}
+}
+
+func (r Result) record() []string {
+	score := ""
+	if r.Error == "" {
+		score = strconv.FormatFloat(r.Score, 'f', -1, 64)
+	}
+
+	return []string{r.Input, r.LastName, r.FirstName, r.MiddleName, score, r.Algorithm, r.Error}
+}
+
+// newDivisionResult returns the Result of d with its romanisation and its reading.
+func newDivisionResult(d division) Result {
+	r := NewResult(d.input, d.name, d.err)
+	if d.err == nil {
+		r.Romanized = d.romanized
+		r.LastNameReading = string(d.reading.LastName)
+		r.FirstNameReading = string(d.reading.FirstName)
+	}
+
+	return r
+}
+
+// division is a divided name, or the error of dividing it, with the line of its input.
+// Line is 0 when the input is not read from a file.
+type division struct {
+	line  int
+	input string
+	// record is the whole row which input is taken from.
+	record []string
+	name   parser.DividedName
+	// reading is the divided reading of name when the input has a reading column.
+	reading parser.DividedName
+	// romanized is the romanisation of reading, or of name without reading, when the romanizer is given.
+	romanized string
+	err       error
+}
+
+type resultWriter interface {
+	write(d division) error
+	flush() error
+}
+
+// newResultWriter returns the writer of f. The CSV and TSV formats have the column romanized when romanized is true.
+func newResultWriter(out, stderr io.Writer, f OutputFormat, romanized bool) resultWriter {
+	switch f {
+	case JSONFormat:
+		return &jsonResultWriter{out: out, count: 0}
+	case JSONLFormat:
+		return jsonlResultWriter{enc: json.NewEncoder(out)}
+	case CSVFormat:
+		return newCSVResultWriter(out, ',', romanized)
+	case TSVFormat:
+		return newCSVResultWriter(out, '\t', romanized)
+	case TextFormat:
+		return textResultWriter{out: out, stderr: stderr}
+	}
+
+	return textResultWriter{out: out, stderr: stderr}
+}
+
+// textResultWriter writes only the divided name, or its romanisation when it has one, to out, and the error to stderr.
+type textResultWriter struct {
+	out    io.Writer
+	stderr io.Writer
+}
+
+func (w textResultWriter) write(d division) error {
+	if d.err != nil {
+		if d.line == 0 {
+			_, err := fmt.Fprintf(w.stderr, "%s\n", d.err.Error())
+			if err != nil {
+				return fmt.Errorf("happen error write stderr: %w", err)
+			}
+
+			return nil
+		}
+
+		_, err := fmt.Fprintf(w.stderr, "parse error on line %d: %v\n", d.line, d.err)
+		if err != nil {
+			return fmt.Errorf("happen error write stderr: %w", err)
+		}
+
+		return nil
+	}
+
+	s := d.name.String()
+	if d.romanized != "" {
+		s = d.romanized
+	}
+
+	_, err := fmt.Fprintf(w.out, "%s\n", s)
+	if err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func (w textResultWriter) flush() error {
+	return nil
+}
+
+// jsonResultWriter writes a JSON array, opened by the first row and closed by flush.
+type jsonResultWriter struct {
+	out   io.Writer
+	count int
+}
+
+func (w *jsonResultWriter) write(d division) error {
+	b, err := json.Marshal(newDivisionResult(d))
+	if err != nil {
+		return fmt.Errorf("happen error encode json: %w", err)
+	}
+
+	prefix := ",\n"
+	if w.count == 0 {
+		prefix = "[\n"
+	}
+
+	w.count++
+
+	if _, err := fmt.Fprintf(w.out, "%s%s", prefix, b); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func (w *jsonResultWriter) flush() error {
+	end := "\n]\n"
+	if w.count == 0 {
+		end = "[]\n"
+	}
+
+	if _, err := io.WriteString(w.out, end); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+type jsonlResultWriter struct {
+	enc *json.Encoder
+}
+
+func (w jsonlResultWriter) write(d division) error {
+	if err := w.enc.Encode(newDivisionResult(d)); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func (w jsonlResultWriter) flush() error {
+	return nil
+}
+
+// csvResultWriter writes resultHeader before the first row. Each row is flushed as soon as it is written.
+type csvResultWriter struct {
+	w      *csv.Writer
+	header bool
+	// romanized adds the column romanized after resultHeader.
+	romanized bool
+}
+
+func newCSVResultWriter(out io.Writer, comma rune, romanized bool) *csvResultWriter {
+	w := csv.NewWriter(out)
+	w.Comma = comma
+
+	return &csvResultWriter{w: w, header: false, romanized: romanized}
+}
+
+func (w *csvResultWriter) writeHeader() error {
+	header := resultHeader
+	if w.romanized {
+		header = append(append([]string{}, resultHeader...), romanizedHeader)
+	}
+
+	if err := w.w.Write(header); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	w.header = true
+
+	return nil
+}
+
+func (w *csvResultWriter) write(d division) error {
+	if !w.header {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	r := newDivisionResult(d)
+
+	record := r.record()
+	if w.romanized {
+		record = append(record, r.Romanized)
+	}
+
+	if err := w.w.Write(record); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return w.flush()
+}
+
+func (w *csvResultWriter) flush() error {
+	if !w.header {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	w.w.Flush()
+
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package seimei_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFile_OutputFormat(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      seimei.OutputFormat
+		want       string
+		wantErrOut string
+	}
+
+	tests := []testdata{
+		{
+			name:  "テキスト",
+			input: seimei.TextFormat,
+			want: `田中 太郎
+竈門 炭治郎
+中曽根 康弘
+`,
+			wantErrOut: "parse error on line 2: parse error: name length needs at least 2 chars\n",
+		},
+		{
+			name:  "JSON",
+			input: seimei.JSONFormat,
+			want: `[
+{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""},
+{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"parse error: name length needs at least 2 chars"},
+{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":""},
+{"input":"中曽根康弘","last_name":"中曽根","first_name":"康弘","score":0.3127240879300895,"algorithm":"statistics","error":""}
+]
+`,
+		},
+		{
+			name:  "JSON Lines",
+			input: seimei.JSONLFormat,
+			want: `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}
+{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"parse error: name length needs at least 2 chars"}
+{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":""}
+{"input":"中曽根康弘","last_name":"中曽根","first_name":"康弘","score":0.3127240879300895,"algorithm":"statistics","error":""}
+`,
+		},
+		{
+			name:  "CSV",
+			input: seimei.CSVFormat,
+			want: `input,last_name,first_name,middle_name,score,algorithm,error
+田中太郎,田中,太郎,,0.319858925466683,statistics,
+乙,,,,,,parse error: name length needs at least 2 chars
+竈門炭治郎,竈門,炭治郎,,0.2472726697308935,statistics,
+中曽根康弘,中曽根,康弘,,0.3127240879300895,statistics,
+`,
+		},
+		{
+			name:  "TSV",
+			input: seimei.TSVFormat,
+			want: "input\tlast_name\tfirst_name\tmiddle_name\tscore\talgorithm\terror\n" +
+				"田中太郎\t田中\t太郎\t\t0.319858925466683\tstatistics\t\n" +
+				"乙\t\t\t\t\t\tparse error: name length needs at least 2 chars\n" +
+				"竈門炭治郎\t竈門\t炭治郎\t\t0.2472726697308935\tstatistics\t\n" +
+				"中曽根康弘\t中曽根\t康弘\t\t0.3127240879300895\tstatistics\t\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			err := seimei.ParseFile(stdout, stderr, "testdata/part_of_error.csv", " ", seimei.WithOutputFormat(tt.input))
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			if diff := cmp.Diff(stdout.String(), tt.want); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if diff := cmp.Diff(stderr.String(), tt.wantErrOut); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseName_OutputFormat(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input seimei.OutputFormat
+		want  string
+	}
+
+	tests := []testdata{
+		{
+			name:  "JSON",
+			input: seimei.JSONFormat,
+			want:  "[\n{\"input\":\"乙一\",\"last_name\":\"乙\",\"first_name\":\"一\",\"score\":1,\"algorithm\":\"rule\",\"error\":\"\"}\n]\n",
+		},
+		{
+			name:  "区切り文字を含む名前もCSVで分けられる",
+			input: seimei.CSVFormat,
+			want:  "input,last_name,first_name,middle_name,score,algorithm,error\n乙一,乙,一,,1,rule,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			if err := seimei.ParseName(stdout, stderr, "乙一", "一", seimei.WithOutputFormat(tt.input)); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			if diff := cmp.Diff(stdout.String(), tt.want); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	got, err := seimei.ParseOutputFormat("jsonl")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+	if got != seimei.JSONLFormat {
+		t.Errorf("failed to test. got: %s, want: %s", got, seimei.JSONLFormat)
+	}
+
+	_, err = seimei.ParseOutputFormat("xml")
+	if !errors.Is(err, seimei.ErrUnknownOutputFormat) {
+		t.Errorf("error is not expected, got error=(%v), want error=(%v)", err, seimei.ErrUnknownOutputFormat)
+	}
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+type BatchOptions struct {
+	// Workers is the number of goroutines dividing names. Values below 1 mean runtime.NumCPU().
+	Workers int
+}
+
+func (o BatchOptions) workers() int {
+	if o.Workers < 1 {
+		return runtime.NumCPU()
+	}
+
+	return o.Workers
+}
+
+// BatchResult is the division of the name at Line, which is the one-based position in the input.
+type BatchResult struct {
+	Line  int
+	Input FullName
+	Name  DividedName
+	Err   error
+}
+
+// ParseBatch divides names concurrently and returns the results in the order of names.
+// An error of a single name is kept in its result, prefixed by the line, and does not stop the others.
+// The returned error is non-nil only when ctx is done before all names are divided.
+func (n NameParser) ParseBatch(ctx context.Context, names []FullName, opts BatchOptions) ([]BatchResult, error) {
+	indexes := make(chan int)
+
+	go func() {
+		defer close(indexes)
+
+		for i := range names {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]BatchResult, 0, len(names))
+
+	for r := range OrderedParallel(ctx, indexes, opts, func(i int) BatchResult {
+		v, err := n.Parse(names[i])
+		if err != nil {
+			err = fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		return BatchResult{
+			Line:  i + 1,
+			Input: names[i],
+			Name:  v,
+			Err:   err,
+		}
+	}) {
+		results = append(results, r)
+	}
+
+	if len(results) < len(names) {
+		return nil, fmt.Errorf("batch canceled: %w", ctx.Err())
+	}
+
+	return results, nil
+}
+
+// OrderedParallel applies f to the values of in with the workers of opts, and sends the results in the order of in.
+// The results stop early when ctx is done. It lets the readers of a stream, such as a file, share ParseBatch's pool.
+func OrderedParallel[T, R any](ctx context.Context, in <-chan T, opts BatchOptions, f func(T) R) <-chan R {
+	type task struct {
+		v      T
+		result chan R
+	}
+
+	workers := opts.workers()
+
+	out := make(chan R)
+	tasks := make(chan task)
+	// queue keeps the results in the order of in, and bounds the number of values in flight.
+	queue := make(chan chan R, workers*2)
+
+	go func() {
+		defer close(tasks)
+		defer close(queue)
+
+		for v := range in {
+			t := task{v: v, result: make(chan R, 1)}
+
+			select {
+			case queue <- t.result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case tasks <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for t := range tasks {
+				t.result <- f(t.v)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+
+		for result := range queue {
+			select {
+			case r := <-result:
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	return out
+}
@@ -0,0 +1,62 @@
+package parser_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+func TestNameParser_ParseBatch(t *testing.T) {
+	t.Parallel()
+
+	sut := parser.NewNameParser("/", seimei.InitKanjiFeatureManager())
+	inputs := []parser.FullName{"田中太郎", "乙", "竈門炭治郎", "中曽根康弘"}
+	names := make([]parser.FullName, 0, len(inputs)*100)
+	for i := 0; i < 100; i++ {
+		names = append(names, inputs...)
+	}
+
+	got, err := sut.ParseBatch(context.Background(), names, parser.BatchOptions{Workers: 4})
+	if err != nil {
+		t.Fatalf("error is not nil, err=%v", err)
+	}
+	if len(got) != len(names) {
+		t.Fatalf("results length is not expected, got=(%d), want=(%d)", len(got), len(names))
+	}
+
+	for i, r := range got {
+		if r.Line != i+1 || r.Input != names[i] {
+			t.Fatalf("result is out of order, got=(%+v), want line=(%d), input=(%s)", r, i+1, names[i])
+		}
+		want, wantErr := sut.Parse(names[i])
+		if wantErr != nil {
+			if !errors.Is(r.Err, parser.ErrNameLength) {
+				t.Errorf("error is not expected, got error=(%v), want error=(%v)", r.Err, parser.ErrNameLength)
+			}
+			if r.Err.Error() != fmt.Sprintf("line %d: %v", i+1, wantErr) {
+				t.Errorf("error message is not expected, got=(%v)", r.Err)
+			}
+			continue
+		}
+		if r.Name != want {
+			t.Errorf("divided name mismatch, got=(%v), want=(%v)", r.Name, want)
+		}
+	}
+}
+
+func TestNameParser_ParseBatch_Canceled(t *testing.T) {
+	t.Parallel()
+
+	sut := parser.NewNameParser("/", seimei.InitKanjiFeatureManager())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sut.ParseBatch(ctx, []parser.FullName{"田中太郎"}, parser.BatchOptions{Workers: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error is not expected, got error=(%v), want error=(%v)", err, context.Canceled)
+	}
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheStats counts the lookups of a CachedNameParser.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type cacheKey struct {
+	fullname  FullName
+	separator Separator
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	name DividedName
+	err  error
+}
+
+// CachedNameParser is a NameParser which keeps the results of the most recently divided names.
+// It is safe for concurrent use.
+type CachedNameParser struct {
+	parser NameParser
+	size   int
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	// order has the most recently used entry at the front.
+	order *list.List
+	stats CacheStats
+}
+
+// NewCachedNameParser keeps the results of at most size names, keyed by the full name and the separator.
+// A size below 1 keeps no results.
+func NewCachedNameParser(p NameParser, size int) *CachedNameParser {
+	return &CachedNameParser{
+		parser:  p,
+		size:    size,
+		entries: make(map[cacheKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Parse returns the kept result of the full name, or divides it with the NameParser and keeps the result.
+// Errors are kept as well, since dividing the same name again fails in the same way.
+func (c *CachedNameParser) Parse(fullname FullName) (DividedName, error) {
+	key := cacheKey{fullname: fullname, separator: c.parser.Separator}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		c.stats.Hits++
+		v := e.Value.(*cacheEntry) //nolint:forcetypeassert
+		c.mu.Unlock()
+
+		return v.name, v.err
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	name, err := c.parser.Parse(fullname)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok || c.size < 1 {
+		return name, err
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, name: name, err: err})
+
+	if c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key) //nolint:forcetypeassert
+	}
+
+	return name, err
+}
+
+// Len returns the number of kept results.
+func (c *CachedNameParser) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Stats returns the number of hits and misses so far.
+func (c *CachedNameParser) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
@@ -0,0 +1,108 @@
+package parser_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCachedNameParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	np := parser.NewNameParser("/", seimei.InitKanjiFeatureManager())
+
+	t.Run("同じ名前は保持した結果を返す", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewCachedNameParser(np, 2)
+		for _, input := range []parser.FullName{"田中太郎", "竈門炭治郎", "田中太郎", "田中太郎"} {
+			got, err := sut.Parse(input)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			want, err := np.Parse(input)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		}
+		if diff := cmp.Diff(sut.Stats(), parser.CacheStats{Hits: 2, Misses: 2}); diff != "" {
+			t.Errorf("stats mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("最も使われていない結果から捨てる", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewCachedNameParser(np, 2)
+		for _, input := range []parser.FullName{"田中太郎", "竈門炭治郎", "田中太郎", "中曽根康弘", "田中太郎", "竈門炭治郎"} {
+			if _, err := sut.Parse(input); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+		}
+		// 竈門炭治郎 is dropped by 中曽根康弘, since 田中太郎 is used after it.
+		if diff := cmp.Diff(sut.Stats(), parser.CacheStats{Hits: 2, Misses: 4}); diff != "" {
+			t.Errorf("stats mismatch (-got +want):\n%s", diff)
+		}
+		if diff := cmp.Diff(sut.Len(), 2); diff != "" {
+			t.Errorf("len mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("エラーも保持する", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewCachedNameParser(np, 2)
+		for i := 0; i < 2; i++ {
+			_, err := sut.Parse("乙")
+			if diff := cmp.Diff(err.Error(), "parse error: name length needs at least 2 chars"); diff != "" {
+				t.Errorf("error mismatch (-got +want):\n%s", diff)
+			}
+		}
+		if diff := cmp.Diff(sut.Stats(), parser.CacheStats{Hits: 1, Misses: 1}); diff != "" {
+			t.Errorf("stats mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("サイズが0なら保持しない", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewCachedNameParser(np, 0)
+		for i := 0; i < 2; i++ {
+			if _, err := sut.Parse("田中太郎"); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+		}
+		if diff := cmp.Diff(sut.Stats(), parser.CacheStats{Hits: 0, Misses: 2}); diff != "" {
+			t.Errorf("stats mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("並行に利用できる", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewCachedNameParser(np, 1)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if _, err := sut.Parse("田中太郎"); err != nil {
+						t.Errorf("happen error: %v", err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		s := sut.Stats()
+		if diff := cmp.Diff(s.Hits+s.Misses, uint64(800)); diff != "" {
+			t.Errorf("stats mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
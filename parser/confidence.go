@@ -0,0 +1,58 @@
+package parser
+
+import "fmt"
+
+// ErrLowConfidence is returned by NameParser.Parse when the best division does not reach
+// NameParser.MinScore or NameParser.MinMargin.
+type ErrLowConfidence struct {
+	// Best is the division which would have been returned without the thresholds.
+	Best DividedName
+	// Margin is the difference between the score of Best and the second-best score.
+	Margin float64
+}
+
+func (e ErrLowConfidence) Error() string {
+	return fmt.Sprintf("low confidence division: best=%s, score=%.4f, margin=%.4f", e.Best.String(), e.Best.Score, e.Margin)
+}
+
+func (n NameParser) checkConfidence(p Parser, fullname FullName, best DividedName) error {
+	if n.MinScore == 0 && n.MinMargin == 0 {
+		return nil
+	}
+
+	margin, err := n.margin(p, fullname, best)
+	if err != nil {
+		return err
+	}
+
+	if best.Score < n.MinScore || margin < n.MinMargin {
+		return ErrLowConfidence{
+			Best:   best,
+			Margin: margin,
+		}
+	}
+
+	return nil
+}
+
+// margin compares best with the runner-up of the parser which produced it.
+// A parser without ranking support has no runner-up, so the margin is the score itself.
+func (n NameParser) margin(p Parser, fullname FullName, best DividedName) (float64, error) {
+	cp, ok := p.(CandidatesParser)
+	if !ok {
+		return best.Score, nil
+	}
+
+	vs, err := cp.ParseCandidates(fullname, n.Separator, 2)
+	if err != nil {
+		return 0, fmt.Errorf("failed margin: %w", err)
+	}
+
+	for _, v := range vs {
+		if v.LastName != best.LastName {
+			return best.Score - v.Score, nil
+		}
+	}
+
+	return best.Score, nil
+}
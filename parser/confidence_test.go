@@ -0,0 +1,114 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNameParser_Parse_Confidence(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name           string
+		input          parser.FullName
+		inputMinScore  float64
+		inputMinMargin float64
+		want           parser.DividedName
+		wantErr        *parser.ErrLowConfidence
+	}
+
+	separator := parser.Separator("/")
+
+	// The margin is subtracted at run time, as NameParser does.
+	best, runnerUp := 0.2472726697308935, 0.21968058013235778
+	margin := best - runnerUp
+
+	tests := []testdata{
+		{
+			name:          "閾値以上のスコアは分割される",
+			input:         "菅義偉",
+			inputMinScore: 0.4,
+			want: parser.DividedName{
+				LastName:  "菅",
+				FirstName: "義偉",
+				Separator: separator,
+				Score:     0.48027055739279506,
+				Algorithm: parser.Statistics,
+			},
+		},
+		{
+			name:          "閾値未満のスコアは最良の候補とともにエラーになる",
+			input:         "竈門炭治郎",
+			inputMinScore: 0.5,
+			wantErr: &parser.ErrLowConfidence{
+				Best: parser.DividedName{
+					LastName:  "竈門",
+					FirstName: "炭治郎",
+					Separator: separator,
+					Score:     0.2472726697308935,
+					Algorithm: parser.Statistics,
+				},
+				Margin: margin,
+			},
+		},
+		{
+			name:           "次点との差が閾値未満はエラーになる",
+			input:          "菅義偉",
+			inputMinMargin: 0.3,
+			wantErr: &parser.ErrLowConfidence{
+				Best: parser.DividedName{
+					LastName:  "菅",
+					FirstName: "義偉",
+					Separator: separator,
+					Score:     0.48027055739279506,
+					Algorithm: parser.Statistics,
+				},
+				Margin: 0.48027055739279506 - 0.27858943555461146,
+			},
+		},
+		{
+			name:           "ルールベースは次点がないので差はスコアと同じ",
+			input:          "中山マサ",
+			inputMinScore:  0.9,
+			inputMinMargin: 0.9,
+			want: parser.DividedName{
+				LastName:  "中山",
+				FirstName: "マサ",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Rule,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+			sut.MinScore = tt.inputMinScore
+			sut.MinMargin = tt.inputMinMargin
+			got, err := sut.Parse(tt.input)
+			if tt.wantErr != nil {
+				var gotErr parser.ErrLowConfidence
+				if !errors.As(err, &gotErr) {
+					t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+				}
+				if diff := cmp.Diff(gotErr, *tt.wantErr); diff != "" {
+					t.Errorf("error mismatch (-got +want):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error is not nil, err=%v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("divided name mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+const Dictionary = Algorithm("dictionary")
+
+var (
+	ErrInvalidDictionaryHeader = errors.New("header of dictionary must be last_name,first_name")
+	ErrInvalidDictionaryRecord = errors.New("record of dictionary must have 2 fields")
+	ErrEmptyDictionaryLastName = errors.New("last name of dictionary must not be empty")
+	ErrDuplicateDictionaryName = errors.New("name is duplicated")
+
+	// DictionaryHeader is the header of a dictionary file.
+	DictionaryHeader = []string{"last_name", "first_name"}
+)
+
+// DictionaryEntry is a record of NameDictionary.
+// An entry with FirstName pins the division of the full name, and an entry without it is a known surname.
+type DictionaryEntry struct {
+	LastName  LastName
+	FirstName FirstName
+}
+
+// IsSurname reports whether e is a known surname rather than a pinned full name.
+func (e DictionaryEntry) IsSurname() bool {
+	return e.FirstName == ""
+}
+
+func (e DictionaryEntry) key() FullName {
+	return JoinName(e.LastName, e.FirstName)
+}
+
+// NameDictionary holds the divisions which are decided in advance instead of being estimated.
+type NameDictionary struct {
+	names    map[FullName]DictionaryEntry
+	surnames map[LastName]struct{}
+	// longest is the length in bytes of the longest surname, which bounds the prefixes looked up.
+	longest int
+}
+
+// NewNameDictionary returns the dictionary of es. A later entry replaces an earlier one of the same name.
+func NewNameDictionary(es ...DictionaryEntry) NameDictionary {
+	d := NameDictionary{
+		names:    make(map[FullName]DictionaryEntry),
+		surnames: make(map[LastName]struct{}),
+		longest:  0,
+	}
+
+	for _, e := range es {
+		d.add(e)
+	}
+
+	return d
+}
+
+func (d *NameDictionary) add(e DictionaryEntry) {
+	if e.IsSurname() {
+		d.surnames[e.LastName] = struct{}{}
+
+		if len(e.LastName) > d.longest {
+			d.longest = len(e.LastName)
+		}
+
+		return
+	}
+
+	d.names[e.key()] = e
+}
+
+// Len returns the number of entries.
+func (d NameDictionary) Len() int {
+	return len(d.names) + len(d.surnames)
+}
+
+// Entries returns the pinned full names followed by the known surnames, each ordered by name.
+func (d NameDictionary) Entries() []DictionaryEntry {
+	names := make([]DictionaryEntry, 0, len(d.names))
+	for _, e := range d.names {
+		names = append(names, e)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].key() < names[j].key()
+	})
+
+	surnames := make([]DictionaryEntry, 0, len(d.surnames))
+	for s := range d.surnames {
+		surnames = append(surnames, DictionaryEntry{LastName: s, FirstName: ""})
+	}
+
+	sort.Slice(surnames, func(i, j int) bool {
+		return surnames[i].LastName < surnames[j].LastName
+	})
+
+	return append(names, surnames...)
+}
+
+// Contains reports whether the dictionary has e.
+func (d NameDictionary) Contains(e DictionaryEntry) bool {
+	if e.IsSurname() {
+		_, ok := d.surnames[e.LastName]
+
+		return ok
+	}
+
+	v, ok := d.names[e.key()]
+
+	return ok && v == e
+}
+
+// lookup returns the pinned division of fullname, or the division after its longest known surname.
+func (d NameDictionary) lookup(fullname FullName) (DictionaryEntry, bool) {
+	if e, ok := d.names[fullname]; ok {
+		return e, true
+	}
+
+	best := 0
+
+	for i := range string(fullname) {
+		if i > d.longest {
+			break
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if _, ok := d.surnames[LastName(fullname[:i])]; ok {
+			best = i
+		}
+	}
+
+	if best == 0 {
+		return DictionaryEntry{}, false
+	}
+
+	return DictionaryEntry{
+		LastName:  LastName(fullname[:best]),
+		FirstName: FirstName(fullname[best:]),
+	}, true
+}
+
+// DictionaryParser divides the full names found in its dictionary.
+// The other full names are left to the following parsers.
+type DictionaryParser struct {
+	Dictionary NameDictionary
+}
+
+func NewDictionaryParser(d NameDictionary) DictionaryParser {
+	return DictionaryParser{
+		Dictionary: d,
+	}
+}
+
+func (p DictionaryParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	e, ok := p.Dictionary.lookup(fullname)
+	if !ok {
+		return DividedName{}, nil
+	}
+
+	return DividedName{
+		LastName:  e.LastName,
+		FirstName: e.FirstName,
+		Separator: separator,
+		Score:     1,
+		Algorithm: Dictionary,
+	}, nil
+}
+
+// WithDictionary returns the name parser whose DictionaryParser divides with d.
+func (n NameParser) WithDictionary(d NameDictionary) NameParser {
+	ps := make([]Parser, 0, len(n.Parsers)+1)
+	found := false
+
+	for _, p := range n.Parsers {
+		if _, ok := p.(DictionaryParser); ok {
+			p = NewDictionaryParser(d)
+			found = true
+		}
+
+		ps = append(ps, p)
+	}
+
+	if !found {
+		ps = append([]Parser{NewDictionaryParser(d)}, ps...)
+	}
+
+	n.Parsers = ps
+
+	return n
+}
+
+// ReadDictionaryCSV reads a dictionary in the format of DictionaryHeader with feature.ReadTable.
+// A name defined twice is reported with the line defining it first.
+func ReadDictionaryCSV(r io.Reader) (NameDictionary, error) {
+	var es []DictionaryEntry
+
+	// A surname and a pinned full name of the same characters are different entries.
+	type entryKey struct {
+		name    FullName
+		surname bool
+	}
+
+	lines := make(map[entryKey]int)
+
+	err := feature.ReadTable(r, DictionaryHeader, ErrInvalidDictionaryHeader, func(line int, record []string) error {
+		e, err := parseDictionaryRecord(record)
+		if err != nil {
+			return err
+		}
+
+		k := entryKey{name: e.key(), surname: e.IsSurname()}
+		if l, ok := lines[k]; ok {
+			return fmt.Errorf("%w: %s is already defined on line %d", ErrDuplicateDictionaryName, e.key(), l)
+		}
+
+		es = append(es, e)
+		lines[k] = line
+
+		return nil
+	})
+	if err != nil {
+		return NameDictionary{}, err
+	}
+
+	return NewNameDictionary(es...), nil
+}
+
+func parseDictionaryRecord(record []string) (DictionaryEntry, error) {
+	if len(record) != len(DictionaryHeader) {
+		return DictionaryEntry{}, fmt.Errorf("%w: got %d fields", ErrInvalidDictionaryRecord, len(record))
+	}
+
+	if record[0] == "" {
+		return DictionaryEntry{}, ErrEmptyDictionaryLastName
+	}
+
+	return DictionaryEntry{
+		LastName:  LastName(record[0]),
+		FirstName: FirstName(record[1]),
+	}, nil
+}
+
+// WriteDictionaryCSV writes the dictionary in the format of DictionaryHeader, in the order of Entries.
+func WriteDictionaryCSV(w io.Writer, d NameDictionary) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(DictionaryHeader); err != nil {
+		return fmt.Errorf("failed write header: %w", err)
+	}
+
+	for _, e := range d.Entries() {
+		if err := cw.Write([]string{string(e.LastName), string(e.FirstName)}); err != nil {
+			return fmt.Errorf("failed write record: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed write dictionary: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package parser_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNameParser_WithDictionary(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input parser.FullName
+		want  parser.DividedName
+	}
+
+	separator := parser.Separator("/")
+
+	dictionary := parser.NewNameDictionary(
+		parser.DictionaryEntry{LastName: "菅義", FirstName: "偉"},
+		parser.DictionaryEntry{LastName: "勅使", FirstName: ""},
+		parser.DictionaryEntry{LastName: "勅使河原", FirstName: ""},
+	)
+
+	tests := []testdata{
+		{
+			name:  "完全一致",
+			input: "菅義偉",
+			want: parser.DividedName{
+				LastName:  "菅義",
+				FirstName: "偉",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Dictionary,
+			},
+		},
+		{
+			name:  "最長の姓で分割する",
+			input: "勅使河原三郎",
+			want: parser.DividedName{
+				LastName:  "勅使河原",
+				FirstName: "三郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Dictionary,
+			},
+		},
+		{
+			name:  "姓と一致する氏名は短い姓で分割する",
+			input: "勅使河原",
+			want: parser.DividedName{
+				LastName:  "勅使",
+				FirstName: "河原",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Dictionary,
+			},
+		},
+		{
+			name:  "辞書にない",
+			input: "中山マサ",
+			want: parser.DividedName{
+				LastName:  "中山",
+				FirstName: "マサ",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Rule,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager()).WithDictionary(dictionary)
+			got, err := sut.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadDictionaryCSV(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      string
+		want       []parser.DictionaryEntry
+		wantErrMsg string
+	}
+
+	tests := []testdata{
+		{
+			name:  "氏名と姓",
+			input: "last_name,first_name\n竈門,炭治郎\n勅使河原,\n竈,門\n竈門,\n",
+			want: []parser.DictionaryEntry{
+				{LastName: "竈", FirstName: "門"},
+				{LastName: "竈門", FirstName: "炭治郎"},
+				{LastName: "勅使河原", FirstName: ""},
+				{LastName: "竈門", FirstName: ""},
+			},
+		},
+		{
+			name:       "空のファイル",
+			input:      "",
+			wantErrMsg: "line 1: header of dictionary must be last_name,first_name: empty file",
+		},
+		{
+			name:       "ヘッダーが不正",
+			input:      "last,first\n竈門,炭治郎\n",
+			wantErrMsg: "line 1: header of dictionary must be last_name,first_name: [last first]",
+		},
+		{
+			name:       "列数が不正",
+			input:      "last_name,first_name\n竈門,炭治郎,かまど\n",
+			wantErrMsg: "line 2: record of dictionary must have 2 fields: got 3 fields",
+		},
+		{
+			name:       "重複",
+			input:      "last_name,first_name\n竈門,炭治郎\n竈,門炭治郎\n",
+			wantErrMsg: "line 3: name is duplicated: 竈門炭治郎 is already defined on line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parser.ReadDictionaryCSV(strings.NewReader(tt.input))
+			if tt.wantErrMsg != "" {
+				if err == nil {
+					t.Fatal("happen no error")
+				}
+				if diff := cmp.Diff(err.Error(), tt.wantErrMsg); diff != "" {
+					t.Fatalf("failed to test on error. diff: %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(got.Entries(), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+
+			var b bytes.Buffer
+			if err := parser.WriteDictionaryCSV(&b, got); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			read, err := parser.ReadDictionaryCSV(&b)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(read.Entries(), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
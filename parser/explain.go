@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+// Explainer is a Parser which can show how each split position was scored.
+type Explainer interface {
+	Parser
+	Explain(fullname FullName, separator Separator) ([]SplitExplanation, error)
+}
+
+// Explanation is the division of a full name with the scores behind it.
+type Explanation struct {
+	Result DividedName
+	// Splits is empty unless Result is divided by StatisticsParser, since the other parsers can not explain their scores.
+	Splits []SplitExplanation
+}
+
+// SplitExplanation shows how one split position was scored.
+type SplitExplanation struct {
+	// Name is the division at this position. Its score is the probability after softmax.
+	Name        DividedName
+	OrderScore  float64
+	LengthScore float64
+	// Score is the combined score before softmax.
+	// It equals OrderScore when the full name has 4 characters, since the length score is not used then.
+	Score      float64
+	Characters []CharacterExplanation
+}
+
+// CharacterExplanation shows what one character contributes to the score of a split.
+type CharacterExplanation struct {
+	Character  feature.Character
+	IsLastName bool
+	Order      float64
+	// OrderMask is nil for the first and the last character, which have no order value.
+	OrderMask  feature.Features
+	Length     float64
+	LengthMask feature.Features
+	// IsDefault reports whether DefaultKanjiFeature was used because the character is not in the table.
+	IsDefault bool
+}
+
+// Explain divides the full name ignoring MinScore and MinMargin, and explains the scores of the first
+// Explainer in the chain when the division is made by StatisticsParser.
+func (n NameParser) Explain(fullname FullName) (Explanation, error) {
+	n.MinScore = 0
+	n.MinMargin = 0
+
+	v, err := n.Parse(fullname)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	if v.Algorithm != Statistics {
+		return Explanation{
+			Result: v,
+			Splits: nil,
+		}, nil
+	}
+
+	for _, p := range n.Parsers {
+		e, ok := p.(Explainer)
+		if !ok {
+			continue
+		}
+
+		splits, err := e.Explain(n.normalize(fullname), n.Separator)
+		if err != nil {
+			return Explanation{}, fmt.Errorf("explain error: %w", err)
+		}
+
+		return Explanation{
+			Result: v,
+			Splits: splits,
+		}, nil
+	}
+
+	return Explanation{
+		Result: v,
+		Splits: nil,
+	}, nil
+}
+
+// Explain returns the scores of every split position which leaves both names non-empty.
+// A full name of 2 characters has no order value at all, so it is not explained.
+func (s StatisticsParser) Explain(fullname FullName, separator Separator) ([]SplitExplanation, error) {
+	if fullname.Length() <= minNameLength {
+		return nil, nil
+	}
+
+	features, err := s.appendScores(nil, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilities := features.SoftMax()
+	splits := make([]SplitExplanation, 0, len(features)-1)
+
+	for i := 1; i < len(features); i++ {
+		l, f, err := fullname.Split(i)
+		if err != nil {
+			return nil, fmt.Errorf("explain error: %w", err)
+		}
+
+		e, err := s.explainSplit(l, f)
+		if err != nil {
+			return nil, fmt.Errorf("explain error: %w", err)
+		}
+
+		e.Name = DividedName{
+			FirstName: f,
+			LastName:  l,
+			Separator: separator,
+			Score:     probabilities[i],
+			Algorithm: Statistics,
+		}
+		splits = append(splits, e)
+	}
+
+	return splits, nil
+}
+
+func (s StatisticsParser) explainSplit(lastName LastName, firstName FirstName) (SplitExplanation, error) {
+	length := JoinName(lastName, firstName).Length()
+
+	olc, err := s.OrderCalculator.Explain(lastName, length)
+	if err != nil {
+		return SplitExplanation{}, fmt.Errorf("failed Order Score: %w", err)
+	}
+
+	ofc, err := s.OrderCalculator.Explain(firstName, length)
+	if err != nil {
+		return SplitExplanation{}, fmt.Errorf("failed Order Score: %w", err)
+	}
+
+	llc, err := s.LengthCalculator.Explain(lastName, length)
+	if err != nil {
+		return SplitExplanation{}, fmt.Errorf("failed Length Score: %w", err)
+	}
+
+	lfc, err := s.LengthCalculator.Explain(firstName, length)
+	if err != nil {
+		return SplitExplanation{}, fmt.Errorf("failed Length Score: %w", err)
+	}
+
+	os := orderScore(length, olc.Sum(), ofc.Sum())
+	ls := lengthScore(length, llc.Sum(), lfc.Sum())
+
+	score := (os + ls) / 2
+	if length == orderOnlyScoreLength {
+		score = os
+	}
+
+	cs := make([]CharacterExplanation, 0, length)
+	cs = appendCharacterExplanations(cs, olc, llc, true)
+	cs = appendCharacterExplanations(cs, ofc, lfc, false)
+
+	//nolint:exhaustivestruct
+	return SplitExplanation{
+		OrderScore:  os,
+		LengthScore: ls,
+		Score:       score,
+		Characters:  cs,
+	}, nil
+}
+
+func appendCharacterExplanations(cs []CharacterExplanation, orders, lengths feature.Contributions, isLastName bool) []CharacterExplanation {
+	for i, o := range orders {
+		cs = append(cs, CharacterExplanation{
+			Character:  o.Character,
+			IsLastName: isLastName,
+			Order:      o.Value,
+			OrderMask:  o.Mask,
+			Length:     lengths[i].Value,
+			LengthMask: lengths[i].Mask,
+			IsDefault:  o.IsDefault,
+		})
+	}
+
+	return cs
+}
@@ -0,0 +1,125 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNameParser_Explain(t *testing.T) {
+	t.Parallel()
+
+	separator := parser.Separator("/")
+
+	t.Run("統計量ベースは分割位置ごとに説明される", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+		got, err := sut.Explain("中曽根康弘")
+		if err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+
+		want := parser.DividedName{
+			LastName:  "中曽根",
+			FirstName: "康弘",
+			Separator: separator,
+			Score:     0.3127240879300895,
+			Algorithm: parser.Statistics,
+		}
+		if diff := cmp.Diff(got.Result, want); diff != "" {
+			t.Errorf("divided name mismatch (-got +want):\n%s", diff)
+		}
+		if len(got.Splits) != 4 {
+			t.Fatalf("splits length is not expected, got=(%d), want=(%d)", len(got.Splits), 4)
+		}
+		if diff := cmp.Diff(got.Splits[2].Name, want); diff != "" {
+			t.Errorf("divided name mismatch (-got +want):\n%s", diff)
+		}
+		for _, s := range got.Splits {
+			if len(s.Characters) != 5 {
+				t.Errorf("characters length is not expected, got=(%d), want=(%d)", len(s.Characters), 5)
+			}
+			if s.Score != (s.OrderScore+s.LengthScore)/2 {
+				t.Errorf("score is not expected, got=(%v), order=(%v), length=(%v)", s.Score, s.OrderScore, s.LengthScore)
+			}
+		}
+
+		first := got.Splits[2].Characters[0]
+		if first.Character != "中" || !first.IsLastName || first.OrderMask != nil || first.Order != 0 {
+			t.Errorf("first character is not expected, got=(%+v)", first)
+		}
+		last := got.Splits[2].Characters[4]
+		if last.Character != "弘" || last.IsLastName {
+			t.Errorf("last character is not expected, got=(%+v)", last)
+		}
+	})
+
+	t.Run("4文字は順序スコアのみ", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+		got, err := sut.Explain("阿部晋三")
+		if err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+		for _, s := range got.Splits {
+			if s.Score != s.OrderScore {
+				t.Errorf("score is not expected, got=(%v), want=(%v)", s.Score, s.OrderScore)
+			}
+		}
+	})
+
+	t.Run("表にない文字はデフォルト扱いになる", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustivestruct
+		sut := parser.NewNameParser(separator, feature.KanjiFeatureManager{})
+		got, err := sut.Explain("やまだはなこ")
+		if err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+		for _, s := range got.Splits {
+			for _, c := range s.Characters {
+				if !c.IsDefault {
+					t.Errorf("character is not default, got=(%+v)", c)
+				}
+			}
+		}
+	})
+
+	t.Run("統計量ベース以外の分割は説明されない", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+		got, err := sut.Explain("中山マサ")
+		if err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+		if got.Result.Algorithm != parser.Rule {
+			t.Errorf("algorithm is not expected, got=(%s), want=(%s)", got.Result.Algorithm, parser.Rule)
+		}
+		if len(got.Splits) != 0 {
+			t.Errorf("splits length is not expected, got=(%d), want=(%d)", len(got.Splits), 0)
+		}
+	})
+
+	t.Run("2文字は説明されない", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+		got, err := sut.Explain("乙一")
+		if err != nil {
+			t.Fatalf("error is not nil, err=%v", err)
+		}
+		if got.Result.Algorithm != parser.Rule {
+			t.Errorf("algorithm is not expected, got=(%s), want=(%s)", got.Result.Algorithm, parser.Rule)
+		}
+		if len(got.Splits) != 0 {
+			t.Errorf("splits length is not expected, got=(%d), want=(%d)", len(got.Splits), 0)
+		}
+	})
+}
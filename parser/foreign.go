@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+const (
+	Foreign       = Algorithm("foreign")
+	foreignPieces = 2
+)
+
+// ErrForeignNames is returned by ForeignParser for a single name with stray delimiters, such as "ジョン・",
+// which the following parsers would divide inside the name.
+var ErrForeignNames = errors.New("foreign name needs 2 names between the delimiters")
+
+// DefaultForeignDelimiters are the delimiters between the names of foreign names written in katakana:
+// a middle dot, a full-width equals sign, an equals sign and a katakana double hyphen.
+func DefaultForeignDelimiters() []rune {
+	return []rune{'・', '＝', '=', '゠'}
+}
+
+// foreignParticles are the particles belonging to the family name which follows them,
+// such as ダ of "レオナルド＝ダ＝ヴィンチ" and ヴァン of "ルートヴィヒ・ヴァン・ベートーヴェン".
+var foreignParticles = map[string]bool{
+	"ダ":   true,
+	"デ":   true,
+	"ディ":  true,
+	"ド":   true,
+	"ドゥ":  true,
+	"デル":  true,
+	"デラ":  true,
+	"ラ":   true,
+	"ル":   true,
+	"ヴァン": true,
+	"ファン": true,
+	"フォン": true,
+}
+
+// ForeignParser divides foreign names written in katakana at the delimiters between the names,
+// such as "ジョン・スミス" and "マリア・テレサ・ロペス".
+// The names are read as the given name first, the family name last and the middle names between them,
+// and the particles before the family name, such as ダ of "レオナルド＝ダ＝ヴィンチ", belong to it.
+// The other full names, including the ones without the delimiters, are left to the following parsers,
+// but a single name with delimiters is ErrForeignNames.
+type ForeignParser struct {
+	Delimiters []rune
+	// FamilyFirst reads the family name first, the given name second and the middle names after them,
+	// such as "バルトーク・ベーラ".
+	FamilyFirst bool
+}
+
+func NewForeignParser(delimiters ...rune) ForeignParser {
+	if len(delimiters) == 0 {
+		delimiters = DefaultForeignDelimiters()
+	}
+
+	return ForeignParser{
+		Delimiters:  delimiters,
+		FamilyFirst: false,
+	}
+}
+
+func (p ForeignParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	s := string(fullname)
+	if !p.isForeignName(s) {
+		return DividedName{}, nil
+	}
+
+	spans := p.split(s)
+	if len(spans) < foreignPieces {
+		return DividedName{}, fmt.Errorf("%w: %s", ErrForeignNames, s)
+	}
+
+	// join returns the names from spans[i] to spans[j], with the delimiters between them as they are.
+	join := func(i, j int) string {
+		if i > j {
+			return ""
+		}
+
+		return s[spans[i][0]:spans[j][1]]
+	}
+
+	last := len(spans) - 1
+	v := DividedName{
+		Separator: separator,
+		Score:     1,
+		Algorithm: Foreign,
+	}
+
+	if p.FamilyFirst {
+		v.LastName = LastName(join(0, 0))
+		v.FirstName = FirstName(join(1, 1))
+		v.MiddleName = MiddleName(join(2, last))
+
+		return v, nil
+	}
+
+	family := last
+	for family > 1 && foreignParticles[join(family-1, family-1)] {
+		family--
+	}
+
+	v.FirstName = FirstName(join(0, 0))
+	v.MiddleName = MiddleName(join(1, family-1))
+	v.LastName = LastName(join(family, last))
+
+	return v, nil
+}
+
+// isForeignName reports whether the name consists of katakana and the delimiters, with a delimiter at least.
+func (p ForeignParser) isForeignName(s string) bool {
+	delimited := false
+
+	for _, r := range s {
+		switch {
+		case p.isDelimiter(r):
+			delimited = true
+		case feature.IsKana(r) && (r == 'ー' || r >= 'ァ'):
+		default:
+			return false
+		}
+	}
+
+	return delimited
+}
+
+// split returns the byte offsets of the start and the end of each name.
+// A run of delimiters counts as one, and delimiters at both ends are ignored.
+func (p ForeignParser) split(s string) [][2]int {
+	var spans [][2]int
+
+	start := -1
+
+	for i, r := range s {
+		if !p.isDelimiter(r) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(s)})
+	}
+
+	return spans
+}
+
+func (p ForeignParser) isDelimiter(r rune) bool {
+	return strings.ContainsRune(string(p.Delimiters), r)
+}
@@ -0,0 +1,154 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestForeignParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name        string
+		input       parser.FullName
+		familyFirst bool
+		want        parser.DividedName
+		wantErr     error
+	}
+
+	separator := parser.Separator("/")
+	tests := []testdata{
+		{
+			name:  "中黒",
+			input: "ジョン・スミス",
+			want: parser.DividedName{
+				LastName:  "スミス",
+				FirstName: "ジョン",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Foreign,
+			},
+		},
+		{
+			name:  "ミドルネーム",
+			input: "マリア・テレサ・ロペス",
+			want: parser.DividedName{
+				LastName:   "ロペス",
+				FirstName:  "マリア",
+				MiddleName: "テレサ",
+				Separator:  separator,
+				Score:      1,
+				Algorithm:  parser.Foreign,
+			},
+		},
+		{
+			name:  "全角の二重ハイフンと姓の前置詞",
+			input: "レオナルド＝ダ＝ヴィンチ",
+			want: parser.DividedName{
+				LastName:  "ダ＝ヴィンチ",
+				FirstName: "レオナルド",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Foreign,
+			},
+		},
+		{
+			name:  "半角の等号と複数のミドルネーム",
+			input: "アン=マリー=ルイーズ=ドルレアン",
+			want: parser.DividedName{
+				LastName:   "ドルレアン",
+				FirstName:  "アン",
+				MiddleName: "マリー=ルイーズ",
+				Separator:  separator,
+				Score:      1,
+				Algorithm:  parser.Foreign,
+			},
+		},
+		{
+			name:        "姓名の順",
+			input:       "バルトーク・ベーラ",
+			familyFirst: true,
+			want: parser.DividedName{
+				LastName:  "バルトーク",
+				FirstName: "ベーラ",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Foreign,
+			},
+		},
+		{
+			name:  "区切りのない名前は扱わない",
+			input: "ジョンスミス",
+			want:  parser.DividedName{},
+		},
+		{
+			name:  "漢字を含む名前は扱わない",
+			input: "山田・太郎",
+			want:  parser.DividedName{},
+		},
+		{
+			name:    "名前が1つでは分割できない",
+			input:   "・ジョン・",
+			want:    parser.DividedName{},
+			wantErr: parser.ErrForeignNames,
+		},
+		{
+			name:    "末尾の区切りだけでは分割できない",
+			input:   "ジョン・",
+			want:    parser.DividedName{},
+			wantErr: parser.ErrForeignNames,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewForeignParser()
+			sut.FamilyFirst = tt.familyFirst
+			got, err := sut.Parse(tt.input, separator)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error mismatch: got=%v, want=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDividedName_String(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input parser.DividedName
+		want  string
+	}
+
+	tests := []testdata{
+		{
+			name:  "姓名",
+			input: parser.DividedName{LastName: "田中", FirstName: "太郎", Separator: " "},
+			want:  "田中 太郎",
+		},
+		{
+			name:  "ミドルネームは姓と名の間",
+			input: parser.DividedName{LastName: "ロペス", FirstName: "マリア", MiddleName: "テレサ", Separator: "/"},
+			want:  "ロペス/テレサ/マリア",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.input.String(), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
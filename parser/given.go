@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	Given       = Algorithm("given")
+	givenPieces = 2
+)
+
+// DefaultDelimiters are the delimiters found in names which are already divided:
+// a space, an ideographic space, a middle dot and a comma.
+func DefaultDelimiters() []rune {
+	return []rune{' ', '\u3000', '・', ','}
+}
+
+// ErrDisagreement is returned by GivenParser when the model divides the full name at another position
+// than the delimiter in the input.
+type ErrDisagreement struct {
+	// Given is the division at the delimiter.
+	Given DividedName
+	// Model is the division of the full name without the delimiter.
+	Model DividedName
+}
+
+func (e ErrDisagreement) Error() string {
+	return fmt.Sprintf("given division disagrees with the model: given=%s, model=%s", e.Given.String(), e.Model.String())
+}
+
+// GivenParser divides a full name at a delimiter already in it, such as the space of "竈門 炭治郎".
+// A run of delimiters counts as one, and delimiters at both ends are ignored.
+// A full name without exactly one delimiter is left to the following parsers.
+type GivenParser struct {
+	Delimiters []rune
+	// Check divides the full name without the delimiter as well when it is not nil.
+	// A different division is returned as ErrDisagreement instead of being trusted.
+	Check *NameParser
+}
+
+func NewGivenParser(delimiters ...rune) GivenParser {
+	if len(delimiters) == 0 {
+		delimiters = DefaultDelimiters()
+	}
+
+	return GivenParser{
+		Delimiters: delimiters,
+		Check:      nil,
+	}
+}
+
+func (p GivenParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	pieces := strings.FieldsFunc(string(fullname), p.isDelimiter)
+	if len(pieces) != givenPieces {
+		return DividedName{}, nil
+	}
+
+	v := DividedName{
+		LastName:  LastName(pieces[0]),
+		FirstName: FirstName(pieces[1]),
+		Separator: separator,
+		Score:     1,
+		Algorithm: Given,
+	}
+
+	if p.Check == nil {
+		return v, nil
+	}
+
+	m, err := p.Check.Parse(JoinName(v.LastName, v.FirstName))
+	if err != nil {
+		return DividedName{}, fmt.Errorf("given parser error: %w", err)
+	}
+
+	if m.LastName != v.LastName || m.FirstName != v.FirstName {
+		return DividedName{}, ErrDisagreement{
+			Given: v,
+			Model: m,
+		}
+	}
+
+	return v, nil
+}
+
+func (p GivenParser) isDelimiter(r rune) bool {
+	for _, d := range p.Delimiters {
+		if r == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithGiven returns the name parser which divides at the delimiters before the other parsers
+// except the dictionary, latin and foreign parsers at the head of the chain, since the delimiters of
+// "Taro Yamada" and "ジョン・スミス" do not tell the order of the names.
+// When check is true, the divisions at the delimiters are checked by the other parsers.
+func (n NameParser) WithGiven(check bool, delimiters ...rune) NameParser {
+	g := NewGivenParser(delimiters...)
+
+	if check {
+		model := n
+		// The full name is already normalized, and the check compares only the divisions.
+		model.Normalizers = nil
+		model.MinScore = 0
+		model.MinMargin = 0
+		g.Check = &model
+	}
+
+	i := 0
+	for i < len(n.Parsers) && precedesGiven(n.Parsers[i]) {
+		i++
+	}
+
+	ps := make([]Parser, 0, len(n.Parsers)+1)
+	ps = append(ps, n.Parsers[:i]...)
+	ps = append(ps, g)
+	n.Parsers = append(ps, n.Parsers[i:]...)
+
+	return n
+}
+
+// precedesGiven reports whether p is tried before GivenParser added by NameParser.WithGiven.
+func precedesGiven(p Parser) bool {
+	switch p.(type) {
+	case DictionaryParser, LatinParser, ForeignParser:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,232 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGivenParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name           string
+		input          parser.FullName
+		inputDelimiter []rune
+		want           parser.DividedName
+	}
+
+	separator := parser.Separator("/")
+
+	tests := []testdata{
+		{
+			name:  "半角スペース",
+			input: "竈門 炭治郎",
+			want: parser.DividedName{
+				LastName:  "竈門",
+				FirstName: "炭治郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:  "全角スペース",
+			input: "竈門　炭治郎",
+			want: parser.DividedName{
+				LastName:  "竈門",
+				FirstName: "炭治郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:  "中黒",
+			input: "竈門・炭治郎",
+			want: parser.DividedName{
+				LastName:  "竈門",
+				FirstName: "炭治郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:  "連続した区切りと前後の区切りは無視する",
+			input: " 竈門, 炭治郎 ",
+			want: parser.DividedName{
+				LastName:  "竈門",
+				FirstName: "炭治郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:           "区切りの指定",
+			input:          "竈門/炭治郎",
+			inputDelimiter: []rune{'/'},
+			want: parser.DividedName{
+				LastName:  "竈門",
+				FirstName: "炭治郎",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:           "指定外の区切りは分割しない",
+			input:          "竈門 炭治郎",
+			inputDelimiter: []rune{'/'},
+			want:           parser.DividedName{},
+		},
+		{
+			name:  "区切りがない",
+			input: "竈門炭治郎",
+			want:  parser.DividedName{},
+		},
+		{
+			name:  "区切りが2つ以上",
+			input: "ジョン・F・ケネディ",
+			want:  parser.DividedName{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewGivenParser(tt.inputDelimiter...)
+			got, err := sut.Parse(tt.input, separator)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNameParser_WithGiven(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      parser.FullName
+		inputCheck bool
+		want       parser.DividedName
+		wantErr    *parser.ErrDisagreement
+	}
+
+	separator := parser.Separator("/")
+
+	tests := []testdata{
+		{
+			name:  "区切りを信頼する",
+			input: "菅義 偉",
+			want: parser.DividedName{
+				LastName:  "菅義",
+				FirstName: "偉",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:  "区切りがなければ統計で分割する",
+			input: "菅義偉",
+			want: parser.DividedName{
+				LastName:  "菅",
+				FirstName: "義偉",
+				Separator: separator,
+				Score:     0.48027055739279506,
+				Algorithm: parser.Statistics,
+			},
+		},
+		{
+			name:  "ローマ字の氏名は区切りで分割しない",
+			input: "Taro Yamada",
+			want: parser.DividedName{
+				LastName:  "Yamada",
+				FirstName: "Taro",
+				Separator: separator,
+				Score:     0.8571428571428571,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "外国人の氏名は中黒で分割しない",
+			input: "ジョン・スミス",
+			want: parser.DividedName{
+				LastName:  "スミス",
+				FirstName: "ジョン",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Foreign,
+			},
+		},
+		{
+			name:       "区切りとモデルが一致する",
+			input:      "菅 義偉",
+			inputCheck: true,
+			want: parser.DividedName{
+				LastName:  "菅",
+				FirstName: "義偉",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Given,
+			},
+		},
+		{
+			name:       "区切りとモデルが一致しない",
+			input:      "菅義 偉",
+			inputCheck: true,
+			wantErr: &parser.ErrDisagreement{
+				Given: parser.DividedName{
+					LastName:  "菅義",
+					FirstName: "偉",
+					Separator: separator,
+					Score:     1,
+					Algorithm: parser.Given,
+				},
+				Model: parser.DividedName{
+					LastName:  "菅",
+					FirstName: "義偉",
+					Separator: separator,
+					Score:     0.48027055739279506,
+					Algorithm: parser.Statistics,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager()).WithGiven(tt.inputCheck)
+			got, err := sut.Parse(tt.input)
+			if tt.wantErr != nil {
+				var gotErr parser.ErrDisagreement
+				if !errors.As(err, &gotErr) {
+					t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+				}
+				if diff := cmp.Diff(gotErr, *tt.wantErr); diff != "" {
+					t.Errorf("error mismatch (-got +want):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error is not nil, err=%v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("divided name mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+const Kana = Algorithm("kana")
+
+// KanaParser divides full names of kana only, such as "やまだはなこ", with the bigram statistics of kana names,
+// since the kanji feature table has little to say about kana.
+// The other full names are left to the following parsers.
+type KanaParser struct {
+	Model feature.KanaModel
+}
+
+func NewKanaParser(m feature.KanaModel) KanaParser {
+	return KanaParser{
+		Model: m,
+	}
+}
+
+func (p KanaParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	vs, err := p.ParseCandidates(fullname, separator, 1)
+	if err != nil || len(vs) == 0 {
+		return DividedName{}, err
+	}
+
+	return vs[0], nil
+}
+
+// ParseCandidates returns at most k divided names ordered by descending probability.
+// A full name which is not kana only has no candidates.
+func (p KanaParser) ParseCandidates(fullname FullName, separator Separator, k int) ([]DividedName, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: k(=%d) must be positive", ErrCandidateSize, k)
+	}
+
+	if !feature.IsKanaName(string(fullname)) || fullname.Length() < minNameLength {
+		return nil, nil
+	}
+
+	// scores[i] is the log probability of the split after i characters, where scores[0] is unused.
+	// A first name can not start with a small kana or ー, so that such a split has no probability.
+	scores := make([]float64, fullname.Length())
+	best := math.Inf(-1)
+
+	for i := 1; i < len(scores); i++ {
+		l, f, err := fullname.Split(i)
+		if err != nil {
+			return nil, fmt.Errorf("kana parser error: %w", err)
+		}
+
+		if isDependentKana([]rune(f)[0]) {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+
+		scores[i] = p.Model.LogProbability(true, string(l)) + p.Model.LogProbability(false, string(f))
+		best = math.Max(best, scores[i])
+	}
+
+	if math.IsInf(best, -1) {
+		return nil, nil
+	}
+
+	// The probabilities are normalised over the splits in the same way as softmax.
+	total := 0.0
+	for i := 1; i < len(scores); i++ {
+		total += math.Exp(scores[i] - best)
+	}
+
+	vs := make([]DividedName, 0, len(scores)-1)
+
+	for i := 1; i < len(scores); i++ {
+		if math.IsInf(scores[i], -1) {
+			continue
+		}
+
+		l, f, err := fullname.Split(i)
+		if err != nil {
+			return nil, fmt.Errorf("kana parser error: %w", err)
+		}
+
+		vs = append(vs, DividedName{
+			LastName:  l,
+			FirstName: f,
+			Separator: separator,
+			Score:     math.Exp(scores[i]-best) / total,
+			Algorithm: Kana,
+		})
+	}
+
+	sort.SliceStable(vs, func(i, j int) bool {
+		return vs[i].Score > vs[j].Score
+	})
+
+	if len(vs) > k {
+		vs = vs[:k]
+	}
+
+	return vs, nil
+}
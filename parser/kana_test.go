@@ -0,0 +1,156 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKanaParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name          string
+		input         parser.FullName
+		wantLastName  parser.LastName
+		wantFirstName parser.FirstName
+		wantHandled   bool
+	}
+
+	tests := []testdata{
+		{
+			name:          "ひらがな",
+			input:         "やまだはなこ",
+			wantLastName:  "やまだ",
+			wantFirstName: "はなこ",
+			wantHandled:   true,
+		},
+		{
+			name:          "カタカナ",
+			input:         "ヤマダハナコ",
+			wantLastName:  "ヤマダ",
+			wantFirstName: "ハナコ",
+			wantHandled:   true,
+		},
+		{
+			name:        "漢字を含む氏名は扱わない",
+			input:       "山田はなこ",
+			wantHandled: false,
+		},
+		{
+			name:        "1文字は扱わない",
+			input:       "や",
+			wantHandled: false,
+		},
+	}
+
+	separator := parser.Separator("/")
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewKanaParser(feature.DefaultKanaModel())
+			got, err := sut.Parse(tt.input, separator)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if !tt.wantHandled {
+				if diff := cmp.Diff(got, parser.DividedName{}); diff != "" {
+					t.Errorf("value mismatch (-got +want):\n%s", diff)
+				}
+				return
+			}
+			if diff := cmp.Diff([]string{string(got.LastName), string(got.FirstName), string(got.Algorithm)},
+				[]string{string(tt.wantLastName), string(tt.wantFirstName), string(parser.Kana)}); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+			if got.Score <= 0.5 || got.Score > 1 {
+				t.Errorf("score is out of range: %v", got.Score)
+			}
+		})
+	}
+}
+
+func TestKanaParser_ParseCandidates(t *testing.T) {
+	t.Parallel()
+
+	sut := parser.NewKanaParser(feature.DefaultKanaModel())
+
+	got, err := sut.ParseCandidates("たなかたろう", "/", 10)
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	if diff := cmp.Diff(len(got), 5); diff != "" {
+		t.Fatalf("value mismatch (-got +want):\n%s", diff)
+	}
+
+	total := 0.0
+	for i, v := range got {
+		total += v.Score
+		if i > 0 && got[i-1].Score < v.Score {
+			t.Errorf("candidates are not ordered: %v < %v", got[i-1].Score, v.Score)
+		}
+	}
+
+	if total < 0.999999 || total > 1.000001 {
+		t.Errorf("scores do not sum up to 1: %v", total)
+	}
+
+	if _, err := sut.ParseCandidates("たなかたろう", "/", 0); !errors.Is(err, parser.ErrCandidateSize) {
+		t.Errorf("error mismatch: got=%v, want=%v", err, parser.ErrCandidateSize)
+	}
+}
+
+func TestKanaParser_ParseCandidates_SmallKana(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		input parser.FullName
+		want  int
+	}
+
+	tests := []testdata{
+		{
+			name:  "拗音の前では分割しない",
+			input: "こちょうしのぶ",
+			want:  5,
+		},
+		{
+			name:  "カタカナの長音の前では分割しない",
+			input: "サトーユーコ",
+			want:  3,
+		},
+		{
+			name:  "分割できる位置がない",
+			input: "しょ",
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewKanaParser(feature.DefaultKanaModel())
+			got, err := sut.ParseCandidates(tt.input, "/", 10)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(len(got), tt.want); diff != "" {
+				t.Fatalf("value mismatch (-got +want):\n%s", diff)
+			}
+			for _, v := range got {
+				switch []rune(string(v.FirstName))[0] {
+				case 'ょ', 'ー':
+					t.Errorf("first name starts with a small kana or a long vowel mark: %v", v)
+				}
+			}
+		})
+	}
+}
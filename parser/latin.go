@@ -0,0 +1,231 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+const (
+	Latin       = Algorithm("latin")
+	latinPieces = 2
+)
+
+// LatinParser divides full names written in the latin script, such as "Taro Yamada", "YAMADA Taro" and "Yamada, Taro",
+// at the white spaces or the comma between the names.
+// The order of the names is told by the conventions first: a comma follows the family name, and the family name
+// alone is written in capitals. Otherwise the counts of romanized surnames and given names decide it,
+// and unknown names are read as the given name first, as the latin script usually is.
+// The names between the given name and the family name, such as "Fitzgerald" of "John Fitzgerald Kennedy",
+// are the middle names, and they follow the given name when the family name comes first.
+// The other full names, including a single name, are left to the following parsers.
+type LatinParser struct {
+	Model feature.LatinModel
+}
+
+func NewLatinParser(m feature.LatinModel) LatinParser {
+	return LatinParser{
+		Model: m,
+	}
+}
+
+func (p LatinParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	vs, err := p.ParseCandidates(fullname, separator, 1)
+	if err != nil || len(vs) == 0 {
+		return DividedName{}, err
+	}
+
+	return vs[0], nil
+}
+
+// ParseCandidates returns at most k divided names ordered by descending probability,
+// which are the two orders of the names unless the conventions tell it.
+// A full name which is not written in the latin script has no candidates.
+func (p LatinParser) ParseCandidates(fullname FullName, separator Separator, k int) ([]DividedName, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: k(=%d) must be positive", ErrCandidateSize, k)
+	}
+
+	if !isLatinName(string(fullname)) {
+		return nil, nil
+	}
+
+	pieces, family := splitLatin(string(fullname))
+	if len(pieces) < latinPieces {
+		return nil, nil
+	}
+
+	if family > 0 {
+		return []DividedName{p.familyFirst(pieces, family, separator, 1)}, nil
+	}
+
+	if i, ok := upperName(pieces); ok {
+		if i == 0 {
+			return []DividedName{p.familyFirst(pieces, 1, separator, 1)}, nil
+		}
+
+		if i == len(pieces)-1 {
+			return []DividedName{p.givenFirst(pieces, separator, 1)}, nil
+		}
+	}
+
+	last := len(pieces) - 1
+	givenFirst := p.Model.LogProbability(true, pieces[last]) + p.Model.LogProbability(false, pieces[0])
+	familyFirst := p.Model.LogProbability(true, pieces[0]) + p.Model.LogProbability(false, pieces[1])
+	// The probabilities are normalised over the two orders in the same way as softmax.
+	best := math.Max(givenFirst, familyFirst)
+	total := math.Exp(givenFirst-best) + math.Exp(familyFirst-best)
+
+	// The given name first comes first, so that it wins a tie.
+	vs := []DividedName{
+		p.givenFirst(pieces, separator, math.Exp(givenFirst-best)/total),
+		p.familyFirst(pieces, 1, separator, math.Exp(familyFirst-best)/total),
+	}
+
+	sort.SliceStable(vs, func(i, j int) bool {
+		return vs[i].Score > vs[j].Score
+	})
+
+	if len(vs) > k {
+		vs = vs[:k]
+	}
+
+	return vs, nil
+}
+
+// givenFirst divides the names as the given name, the middle names and the family name in order.
+func (p LatinParser) givenFirst(pieces []string, separator Separator, score float64) DividedName {
+	last := len(pieces) - 1
+
+	return p.divide(pieces[last:], pieces[0], pieces[1:last], separator, score)
+}
+
+// familyFirst divides the names as the family name of the first n names, the given name and the middle names in order.
+func (p LatinParser) familyFirst(pieces []string, n int, separator Separator, score float64) DividedName {
+	return p.divide(pieces[:n], pieces[n], pieces[n+1:], separator, score)
+}
+
+func (p LatinParser) divide(family []string, given string, middle []string, separator Separator, score float64) DividedName {
+	return DividedName{
+		LastName:   LastName(strings.Join(family, " ")),
+		FirstName:  FirstName(given),
+		MiddleName: MiddleName(strings.Join(middle, " ")),
+		Separator:  separator,
+		Score:      score,
+		Algorithm:  Latin,
+	}
+}
+
+// isLatinName reports whether the name consists of latin letters, white spaces and commas,
+// with a letter at least. Apostrophes and hyphens are only within a name, such as "O'Brien" and "Jean-Luc".
+func isLatinName(s string) bool {
+	letter := false
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) && feature.IsLatin(r):
+			letter = true
+		case feature.IsLatin(r), isLatinDelimiter(r):
+		default:
+			return false
+		}
+	}
+
+	for _, v := range strings.FieldsFunc(s, isLatinDelimiter) {
+		if strings.TrimFunc(v, isLatinMark) != v {
+			return false
+		}
+	}
+
+	return letter
+}
+
+// isLatinMark reports whether r is an apostrophe or a hyphen.
+func isLatinMark(r rune) bool {
+	return feature.IsLatin(r) && !unicode.IsLetter(r)
+}
+
+// splitLatin returns the names split at white spaces and commas, and the count of the names before the comma,
+// which is 0 without a comma followed by a given name.
+// A name without them, such as "TaroYamada" whose space is removed by SpaceNormalizer,
+// is split before the capital letter following a lower case one.
+func splitLatin(s string) ([]string, int) {
+	pieces := strings.FieldsFunc(s, isLatinDelimiter)
+
+	if len(pieces) != 1 {
+		before, _, comma := strings.Cut(s, ",")
+		if !comma {
+			return pieces, 0
+		}
+
+		if family := len(strings.FieldsFunc(before, isLatinDelimiter)); family < len(pieces) {
+			return pieces, family
+		}
+
+		return pieces, 0
+	}
+
+	rs := []rune(pieces[0])
+	at := 0
+
+	for i := 1; i < len(rs); i++ {
+		if unicode.IsLower(rs[i-1]) && unicode.IsUpper(rs[i]) {
+			if at > 0 {
+				return pieces, 0
+			}
+
+			at = i
+		}
+	}
+
+	if at == 0 {
+		return pieces, 0
+	}
+
+	return []string{string(rs[:at]), string(rs[at:])}, 0
+}
+
+func isLatinDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == ','
+}
+
+// upperName returns the index of the only name written in capitals, such as "YAMADA" of "YAMADA Taro".
+func upperName(pieces []string) (int, bool) {
+	at := -1
+
+	for i, v := range pieces {
+		if !isUpperName(v) {
+			continue
+		}
+
+		if at >= 0 {
+			return 0, false
+		}
+
+		at = i
+	}
+
+	return at, at >= 0
+}
+
+// isUpperName reports whether the name is written in capitals, such as "YAMADA".
+// A name of one letter, such as an initial, is not.
+func isUpperName(s string) bool {
+	letters := 0
+
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+
+		if unicode.IsUpper(r) {
+			letters++
+		}
+	}
+
+	return letters > 1
+}
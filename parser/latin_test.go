@@ -0,0 +1,233 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLatinParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   parser.FullName
+		want    parser.DividedName
+		wantErr error
+	}
+
+	separator := parser.Separator("/")
+	tests := []testdata{
+		{
+			name:  "名姓の順",
+			input: "Taro Yamada",
+			want: parser.DividedName{
+				LastName:  "Yamada",
+				FirstName: "Taro",
+				Separator: separator,
+				Score:     0.8571428571428571,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "小文字の姓名の順",
+			input: "yamada taro",
+			want: parser.DividedName{
+				LastName:  "yamada",
+				FirstName: "taro",
+				Separator: separator,
+				Score:     0.8571428571428571,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "大文字の姓",
+			input: "Hanako SATO",
+			want: parser.DividedName{
+				LastName:  "SATO",
+				FirstName: "Hanako",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "カンマの前の姓",
+			input: "Taro, Yamada",
+			want: parser.DividedName{
+				LastName:  "Taro",
+				FirstName: "Yamada",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "空白のない名前",
+			input: "TaroYamada",
+			want: parser.DividedName{
+				LastName:  "Yamada",
+				FirstName: "Taro",
+				Separator: separator,
+				Score:     0.8571428571428571,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "未知の名前は名姓の順",
+			input: "John Smith",
+			want: parser.DividedName{
+				LastName:  "Smith",
+				FirstName: "John",
+				Separator: separator,
+				Score:     0.5,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "漢字は扱わない",
+			input: "山田 太郎",
+			want:  parser.DividedName{},
+		},
+		{
+			name:  "ミドルネーム",
+			input: "John Fitzgerald Kennedy",
+			want: parser.DividedName{
+				LastName:   "Kennedy",
+				FirstName:  "John",
+				MiddleName: "Fitzgerald",
+				Separator:  separator,
+				Score:      0.5,
+				Algorithm:  parser.Latin,
+			},
+		},
+		{
+			name:  "大文字の姓に続くミドルネーム",
+			input: "TOLKIEN John Ronald Reuel",
+			want: parser.DividedName{
+				LastName:   "TOLKIEN",
+				FirstName:  "John",
+				MiddleName: "Ronald Reuel",
+				Separator:  separator,
+				Score:      1,
+				Algorithm:  parser.Latin,
+			},
+		},
+		{
+			name:  "カンマの前の複数の姓",
+			input: "van Gogh, Vincent Willem",
+			want: parser.DividedName{
+				LastName:   "van Gogh",
+				FirstName:  "Vincent",
+				MiddleName: "Willem",
+				Separator:  separator,
+				Score:      1,
+				Algorithm:  parser.Latin,
+			},
+		},
+		{
+			name:  "1つの名前は扱わない",
+			input: "abc",
+			want:  parser.DividedName{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewLatinParser(feature.DefaultLatinModel())
+			got, err := sut.Parse(tt.input, separator)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error mismatch: got=%v, want=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLatinParser_Parse_Marks(t *testing.T) {
+	t.Parallel()
+
+	m := feature.NewLatinModel()
+	for _, v := range [][2]string{{"O'Brien", "Sean"}, {"Picard", "Jean-Luc"}, {"Yamada", "Hanako"}, {"Sato", "Taro"}} {
+		if err := m.Add(parser.LastName(v[0]), parser.FirstName(v[1])); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+	}
+
+	type testdata struct {
+		name  string
+		input parser.FullName
+		want  string
+	}
+
+	tests := []testdata{
+		{name: "アポストロフィを含む姓が先", input: "O'Brien Sean", want: "O'Brien/Sean"},
+		{name: "アポストロフィを含む姓が後", input: "Sean O'Brien", want: "O'Brien/Sean"},
+		{name: "折り畳まれたアポストロフィ", input: "OBrien Sean", want: "OBrien/Sean"},
+		{name: "ハイフンを含む名", input: "Picard Jean-Luc", want: "Picard/Jean-Luc"},
+		{name: "表にない複合姓は各姓で数える", input: "Mika Yamada-Sato", want: "Yamada-Sato/Mika"},
+		{name: "表にない複合姓が先", input: "Yamada-Sato Mika", want: "Yamada-Sato/Mika"},
+		{name: "ハイフンだけの名前は扱わない", input: "- Taro", want: ""},
+		{name: "末尾のアポストロフィは扱わない", input: "Taro Yamada'", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewLatinParser(m)
+			got, err := sut.Parse(tt.input, "/")
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if got.IsZero() {
+				if tt.want != "" {
+					t.Errorf("failed to test. want: %s", tt.want)
+				}
+
+				return
+			}
+			if diff := cmp.Diff(got.String(), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+			if got.Score <= 0.5 {
+				t.Errorf("the counts do not decide the order: %v", got.Score)
+			}
+		})
+	}
+}
+
+func TestLatinParser_ParseCandidates(t *testing.T) {
+	t.Parallel()
+
+	sut := parser.NewLatinParser(feature.DefaultLatinModel())
+
+	got, err := sut.ParseCandidates("Yamada Taro", "/", 2)
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := []string{"Yamada/Taro", "Taro/Yamada"}
+	gotNames := make([]string, 0, len(got))
+	total := 0.0
+
+	for _, v := range got {
+		gotNames = append(gotNames, v.String())
+		total += v.Score
+	}
+
+	if diff := cmp.Diff(gotNames, want); diff != "" {
+		t.Errorf("value mismatch (-got +want):\n%s", diff)
+	}
+
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("scores do not sum to 1: %v", total)
+	}
+}
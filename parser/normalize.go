@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Normalizer rewrites a full name before it is divided.
+type Normalizer interface {
+	Normalize(fullname FullName) FullName
+}
+
+// NFKCNormalizer applies the Unicode normalization form NFKC,
+// which turns half-width katakana into full-width and full-width alphanumerics into ASCII.
+type NFKCNormalizer struct{}
+
+func (NFKCNormalizer) Normalize(fullname FullName) FullName {
+	return FullName(norm.NFKC.String(string(fullname)))
+}
+
+// WidthNormalizer folds every character to its canonical width without the other NFKC mappings.
+// The voiced sound marks of half-width katakana are composed with NFC, so that ﾀﾞ becomes ダ.
+type WidthNormalizer struct{}
+
+func (WidthNormalizer) Normalize(fullname FullName) FullName {
+	return FullName(norm.NFC.String(width.Fold.String(string(fullname))))
+}
+
+// SpaceNormalizer removes white spaces, including U+3000, and zero-width characters.
+type SpaceNormalizer struct{}
+
+func (SpaceNormalizer) Normalize(fullname FullName) FullName {
+	return FullName(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || isZeroWidth(r) {
+			return -1
+		}
+
+		return r
+	}, string(fullname)))
+}
+
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+		return true
+	}
+
+	return false
+}
+
+// DefaultNormalizers returns the normalizers for names typed into forms, in the order they are applied.
+func DefaultNormalizers() []Normalizer {
+	return []Normalizer{NFKCNormalizer{}, WidthNormalizer{}, SpaceNormalizer{}}
+}
+
+// normalize applies the normalizers of the NameParser in order.
+func (n NameParser) normalize(fullname FullName) FullName {
+	for _, v := range n.Normalizers {
+		fullname = v.Normalize(fullname)
+	}
+
+	return fullname
+}
+
+// withInput records the input of a divided name when the NameParser normalizes it.
+func (n NameParser) withInput(v DividedName, original, normalized FullName) DividedName {
+	if len(n.Normalizers) == 0 || v.IsZero() {
+		return v
+	}
+
+	v.Original = original
+	v.Normalized = normalized
+
+	return v
+}
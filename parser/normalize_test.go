@@ -0,0 +1,122 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizer(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name  string
+		sut   parser.Normalizer
+		input parser.FullName
+		want  parser.FullName
+	}
+	tests := []testdata{
+		{
+			name:  "NFKCで半角カナを全角にする",
+			sut:   parser.NFKCNormalizer{},
+			input: "ﾔﾏﾀﾞﾀﾛｳ",
+			want:  "ヤマダタロウ",
+		},
+		{
+			name:  "NFKCで全角英数字を半角にする",
+			sut:   parser.NFKCNormalizer{},
+			input: "ＡＢＣ１２３",
+			want:  "ABC123",
+		},
+		{
+			name:  "NFKCで互換漢字を統合漢字にする",
+			sut:   parser.NFKCNormalizer{},
+			input: "豈",
+			want:  "豈",
+		},
+		{
+			name:  "幅の統一",
+			sut:   parser.WidthNormalizer{},
+			input: "ﾔﾏﾀﾞＡ",
+			want:  "ヤマダA",
+		},
+		{
+			name:  "空白とゼロ幅文字の除去",
+			sut:   parser.SpaceNormalizer{},
+			input: " 田中　​太郎\n",
+			want:  "田中太郎",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.sut.Normalize(tt.input), tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNameParser_ParseNormalized(t *testing.T) {
+	t.Parallel()
+
+	sut := parser.NewNameParser("/", seimei.InitKanjiFeatureManager())
+	sut.Normalizers = parser.DefaultNormalizers()
+
+	t.Run("正規化した名前を分割し元の入力も残す", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := sut.Parse("​竈門　炭治郎 ")
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		want := parser.DividedName{
+			LastName:   "竈門",
+			FirstName:  "炭治郎",
+			Separator:  "/",
+			Score:      0.2472726697308935,
+			Algorithm:  parser.Statistics,
+			Original:   "​竈門　炭治郎 ",
+			Normalized: "竈門炭治郎",
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("value mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("正規化後の長さで検証する", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sut.Parse("乙　")
+		if diff := cmp.Diff(err.Error(), "parse error: name length needs at least 2 chars"); diff != "" {
+			t.Errorf("value mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("候補にも元の入力を残す", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := sut.ParseCandidates("ﾔﾏﾀﾞ太郎", 1)
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		want := []parser.DividedName{
+			{
+				LastName:   "ヤマダ",
+				FirstName:  "太郎",
+				Separator:  "/",
+				Score:      1,
+				Algorithm:  parser.Rule,
+				Original:   "ﾔﾏﾀﾞ太郎",
+				Normalized: "ヤマダ太郎",
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("value mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
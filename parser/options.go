@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+// NameParserOption changes the name parser built by NewNameParser.
+type NameParserOption func(*nameParserConfig)
+
+type nameParserConfig struct {
+	// parsers replaces the whole chain when it is not nil.
+	parsers           []Parser
+	algorithms        []Algorithm
+	withoutLatin      bool
+	withoutForeign    bool
+	familyFirst       bool
+	withoutRule       bool
+	withoutStatistics bool
+	statisticsOptions []StatisticsOption
+	kanaModel         *feature.KanaModel
+	latinModel        *feature.LatinModel
+	normalizers       []Normalizer
+	minScore          float64
+	minMargin         float64
+}
+
+// chain returns the parsers tried in order. The dictionary comes first so that NameParser.WithDictionary
+// takes precedence over the others.
+func (c nameParserConfig) chain(m feature.KanjiFeatureManager) []Parser {
+	if c.parsers != nil {
+		return c.parsers
+	}
+
+	s := make([]Parser, 0)
+	// The dictionary is empty until it is given by NameParser.WithDictionary.
+	s = append(s, NewDictionaryParser(NameDictionary{}))
+
+	if c.algorithms != nil {
+		ps, err := NewParsers(m, c.algorithms...)
+		if err != nil {
+			panic(fmt.Sprintf("parser: %v", err))
+		}
+
+		// The registered kana, latin, foreign and statistics parsers have the default models, order and options.
+		for i, p := range ps {
+			switch p.(type) {
+			case KanaParser:
+				ps[i] = NewKanaParser(c.kana())
+			case LatinParser:
+				ps[i] = NewLatinParser(c.latin())
+			case ForeignParser:
+				ps[i] = c.foreign()
+			case StatisticsParser:
+				ps[i] = NewStatisticsParser(m, c.statisticsOptions...)
+			}
+		}
+
+		return append(s, ps...)
+	}
+
+	// KanaParser is left out by default, since the embedded kana names do not divide better than StatisticsParser.
+	if c.kanaModel != nil {
+		s = append(s, NewKanaParser(*c.kanaModel))
+	}
+
+	if !c.withoutLatin {
+		s = append(s, NewLatinParser(c.latin()))
+	}
+
+	if !c.withoutForeign {
+		s = append(s, c.foreign())
+	}
+
+	if !c.withoutRule {
+		s = append(s, NewRuleBaseParser())
+	}
+
+	if !c.withoutStatistics {
+		s = append(s, NewStatisticsParser(m, c.statisticsOptions...))
+	}
+
+	return s
+}
+
+func (c nameParserConfig) kana() feature.KanaModel {
+	if c.kanaModel != nil {
+		return *c.kanaModel
+	}
+
+	return feature.DefaultKanaModel()
+}
+
+func (c nameParserConfig) latin() feature.LatinModel {
+	if c.latinModel != nil {
+		return *c.latinModel
+	}
+
+	return feature.DefaultLatinModel()
+}
+
+func (c nameParserConfig) foreign() ForeignParser {
+	p := NewForeignParser()
+	p.FamilyFirst = c.familyFirst
+
+	return p
+}
+
+// WithParsers divides names with ps in order instead of the default chain.
+func WithParsers(ps ...Parser) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.parsers = ps
+	}
+}
+
+// WithAlgorithms divides names with the parsers registered for as in order instead of LatinParser,
+// ForeignParser, RuleBaseParser and StatisticsParser. NewNameParser panics when an algorithm is not registered,
+// so the algorithms given by users should be looked up by LookupParser beforehand.
+func WithAlgorithms(as ...Algorithm) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.algorithms = as
+	}
+}
+
+// WithKana puts KanaParser with feature.DefaultKanaModel at the head of the default chain.
+func WithKana() NameParserOption {
+	return WithKanaModel(feature.DefaultKanaModel())
+}
+
+// WithKanaModel puts KanaParser with m at the head of the default chain, and makes the kana algorithm use m
+// instead of feature.DefaultKanaModel.
+func WithKanaModel(m feature.KanaModel) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.kanaModel = &m
+	}
+}
+
+// WithoutLatin leaves LatinParser out of the default chain, so that names in the latin script are divided by the others.
+func WithoutLatin() NameParserOption {
+	return func(c *nameParserConfig) {
+		c.withoutLatin = true
+	}
+}
+
+// WithLatinModel orders the names in the latin script with m instead of feature.DefaultLatinModel.
+func WithLatinModel(m feature.LatinModel) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.latinModel = &m
+	}
+}
+
+// WithoutForeign leaves ForeignParser out of the default chain.
+func WithoutForeign() NameParserOption {
+	return func(c *nameParserConfig) {
+		c.withoutForeign = true
+	}
+}
+
+// WithForeignFamilyFirst reads the foreign names in katakana as the family name first, such as "バルトーク・ベーラ".
+func WithForeignFamilyFirst() NameParserOption {
+	return func(c *nameParserConfig) {
+		c.familyFirst = true
+	}
+}
+
+// WithoutRule leaves RuleBaseParser out of the default chain.
+func WithoutRule() NameParserOption {
+	return func(c *nameParserConfig) {
+		c.withoutRule = true
+	}
+}
+
+// WithoutStatistics leaves StatisticsParser out of the default chain.
+func WithoutStatistics() NameParserOption {
+	return func(c *nameParserConfig) {
+		c.withoutStatistics = true
+	}
+}
+
+// WithStatisticsOptions builds StatisticsParser of the default chain or of WithAlgorithms with opts.
+func WithStatisticsOptions(opts ...StatisticsOption) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.statisticsOptions = append(c.statisticsOptions, opts...)
+	}
+}
+
+// WithNormalizers sets NameParser.Normalizers.
+func WithNormalizers(ns ...Normalizer) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.normalizers = ns
+	}
+}
+
+// WithMinScore sets NameParser.MinScore.
+func WithMinScore(v float64) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.minScore = v
+	}
+}
+
+// WithMinMargin sets NameParser.MinMargin.
+func WithMinMargin(v float64) NameParserOption {
+	return func(c *nameParserConfig) {
+		c.minMargin = v
+	}
+}
+
+// StatisticsOption changes the statistics parser built by NewStatisticsParser.
+type StatisticsOption func(*StatisticsParser)
+
+// WithIndex scores names with x instead of the index of the kanji feature table.
+func WithIndex(x *feature.KanjiFeatureIndex) StatisticsOption {
+	return func(s *StatisticsParser) {
+		s.Index = x
+	}
+}
+
+// WithoutIndex scores names with the calculators, which allocate but need no index.
+func WithoutIndex() StatisticsOption {
+	return func(s *StatisticsParser) {
+		s.Index = nil
+	}
+}
@@ -0,0 +1,185 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewNameParser_Options(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   parser.FullName
+		options []parser.NameParserOption
+		want    parser.DividedName
+		wantErr error
+	}
+
+	separator := parser.Separator("/")
+
+	statistics := parser.DividedName{
+		LastName:  "中山",
+		FirstName: "マサ",
+		Separator: separator,
+		Score:     0.3528141020006961,
+		Algorithm: parser.Statistics,
+	}
+
+	// The margin is subtracted at run time, as NameParser does.
+	runnerUp := 0.21660061315340182
+	margin := statistics.Score - runnerUp
+
+	tests := []testdata{
+		{
+			name:  "既定",
+			input: "中山マサ",
+			want: parser.DividedName{
+				LastName:  "中山",
+				FirstName: "マサ",
+				Separator: separator,
+				Score:     1,
+				Algorithm: parser.Rule,
+			},
+		},
+		{
+			name:    "ルールベースを除く",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithoutRule()},
+			want:    statistics,
+		},
+		{
+			name:    "統計のみのアルゴリズム",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithAlgorithms(parser.Statistics)},
+			want:    statistics,
+		},
+		{
+			name:    "統計の索引を使わない",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithoutRule(), parser.WithStatisticsOptions(parser.WithoutIndex())},
+			want:    statistics,
+		},
+		{
+			name:    "パーサーの指定",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithParsers(parser.NewStatisticsParser(seimei.InitKanjiFeatureManager()))},
+			want:    statistics,
+		},
+		{
+			name:    "かなのパーサーを加える",
+			input:   "やまだはなこ",
+			options: []parser.NameParserOption{parser.WithKana()},
+			want: parser.DividedName{
+				LastName:  "やまだ",
+				FirstName: "はなこ",
+				Separator: separator,
+				Score:     0.9963004353187136,
+				Algorithm: parser.Kana,
+			},
+		},
+		{
+			name:    "すべて除くと分割できない",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithoutRule(), parser.WithoutStatistics()},
+			wantErr: parser.ErrParserNotWorking,
+		},
+		{
+			name:    "閾値の指定",
+			input:   "中山マサ",
+			options: []parser.NameParserOption{parser.WithoutRule(), parser.WithMinScore(0.5)},
+			wantErr: parser.ErrLowConfidence{Best: statistics, Margin: margin},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager(), tt.options...)
+			got, err := sut.Parse(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewNameParser_Algorithms(t *testing.T) {
+	t.Parallel()
+
+	t.Run("統計のオプションが反映される", func(t *testing.T) {
+		t.Parallel()
+
+		sut := parser.NewNameParser("/", seimei.InitKanjiFeatureManager(),
+			parser.WithAlgorithms(parser.Statistics), parser.WithStatisticsOptions(parser.WithoutIndex()))
+		got, ok := sut.Parsers[len(sut.Parsers)-1].(parser.StatisticsParser)
+		if !ok {
+			t.Fatalf("last parser is not StatisticsParser: %T", sut.Parsers[len(sut.Parsers)-1])
+		}
+		if got.Index != nil {
+			t.Errorf("index is not nil: %v", got.Index)
+		}
+	})
+
+	t.Run("未登録のアルゴリズム", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if recover() == nil {
+				t.Error("happen no panic")
+			}
+		}()
+
+		parser.NewNameParser("/", seimei.InitKanjiFeatureManager(), parser.WithAlgorithms("foo"))
+	})
+}
+
+func TestNewParsers(t *testing.T) {
+	t.Parallel()
+
+	got, err := parser.NewParsers(seimei.InitKanjiFeatureManager(), parser.Statistics, parser.Rule)
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	if _, ok := got[0].(parser.StatisticsParser); !ok {
+		t.Errorf("first parser is not StatisticsParser: %T", got[0])
+	}
+
+	if _, ok := got[1].(parser.RuleBaseParser); !ok {
+		t.Errorf("second parser is not RuleBaseParser: %T", got[1])
+	}
+
+	if _, err := parser.NewParsers(seimei.InitKanjiFeatureManager(), "foo"); !errors.Is(err, parser.ErrUnknownAlgorithm) {
+		t.Errorf("error is not expected, got error=(%v), want error=(%v)", err, parser.ErrUnknownAlgorithm)
+	}
+}
+
+func TestRegisterParser_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("happen no panic")
+		}
+	}()
+
+	parser.RegisterParser(parser.Rule, func(feature.KanjiFeatureManager) parser.Parser {
+		return parser.NewRuleBaseParser()
+	})
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+// readingLengths is the rough probability of the number of kana read for a kanji in names,
+// up to the longest one such as "こころざし" of 志.
+var readingLengths = []float64{0, 0.2, 0.5, 0.25, 0.04, 0.01}
+
+// ErrUnreconciled is returned by NameParser.ParsePair when no division of the reading fits any division of the name.
+type ErrUnreconciled struct {
+	// Name and Reading are the best divisions of each made separately.
+	Name    DividedName
+	Reading DividedName
+}
+
+func (e ErrUnreconciled) Error() string {
+	return fmt.Sprintf("reading cannot be reconciled with the name: name=%s, reading=%s", e.Name.String(), e.Reading.String())
+}
+
+// DividedPair is a divided name and its reading divided at the corresponding position.
+type DividedPair struct {
+	Name    DividedName
+	Reading DividedName
+	// Score is the probability of the pair among the pairs whose lengths fit.
+	Score float64
+}
+
+// ParsePair divides the full name, such as "竈門炭治郎", and its kana reading, such as "カマドタンジロウ", together.
+// Of the candidates of both, the pair is chosen by the product of their scores and the likelihood of the lengths:
+// each kanji is read as 1 to 5 kana, which does not start with a small kana, and each kana in the name is read as itself.
+// The pair is ErrUnreconciled when no reading fits.
+// MinScore and MinMargin are not applied, since the reading confirms the division instead.
+func (n NameParser) ParsePair(fullname, reading FullName) (DividedPair, error) {
+	names, err := n.ParseCandidates(fullname, maxCandidates(n.normalize(fullname)))
+	if err != nil {
+		return DividedPair{}, err
+	}
+
+	readings, err := n.ParseCandidates(reading, maxCandidates(n.normalize(reading)))
+	if err != nil {
+		return DividedPair{}, fmt.Errorf("failed reading: %w", err)
+	}
+
+	var (
+		best  DividedPair
+		total float64
+	)
+
+	for _, name := range names {
+		for _, r := range readings {
+			l := readingLikelihood(name.LastName.Slice(), r.LastName.Slice()) *
+				readingLikelihood(name.FirstName.Slice(), r.FirstName.Slice())
+			if l == 0 {
+				continue
+			}
+
+			s := name.Score * r.Score * l
+			total += s
+
+			if s > best.Score {
+				best = DividedPair{Name: name, Reading: r, Score: s}
+			}
+		}
+	}
+
+	if total == 0 {
+		return DividedPair{}, ErrUnreconciled{
+			Name:    names[0],
+			Reading: readings[0],
+		}
+	}
+
+	best.Score /= total
+
+	return best, nil
+}
+
+// maxCandidates is the number of every division of the full name.
+func maxCandidates(fullname FullName) int {
+	if fullname.Length() < minNameLength {
+		return 1
+	}
+
+	return fullname.Length() - 1
+}
+
+// readingLikelihood returns the likelihood of the reading being the kana of the piece of name, or 0 when it cannot be.
+// A kana in the piece is read as itself regardless of hiragana and katakana.
+func readingLikelihood(piece, reading []rune) float64 {
+	// likelihoods[i][j] is the likelihood of piece[i:] being read as reading[j:].
+	likelihoods := make([][]float64, len(piece)+1)
+	for i := range likelihoods {
+		likelihoods[i] = make([]float64, len(reading)+1)
+	}
+
+	likelihoods[len(piece)][len(reading)] = 1
+
+	for i := len(piece) - 1; i >= 0; i-- {
+		for j := len(reading) - 1; j >= 0; j-- {
+			if feature.IsKana(piece[i]) {
+				if feature.FoldKana(piece[i]) == feature.FoldKana(reading[j]) {
+					likelihoods[i][j] = likelihoods[i+1][j+1]
+				}
+
+				continue
+			}
+
+			if isDependentKana(reading[j]) {
+				continue
+			}
+
+			for l := 1; l < len(readingLengths) && j+l <= len(reading); l++ {
+				likelihoods[i][j] += readingLengths[l] * likelihoods[i+1][j+l]
+			}
+		}
+	}
+
+	return likelihoods[0][0]
+}
+
+// isDependentKana reports whether r cannot start the reading of a kanji, such as ょ and ー.
+func isDependentKana(r rune) bool {
+	switch feature.FoldKana(r) {
+	case 'ぁ', 'ぃ', 'ぅ', 'ぇ', 'ぉ', 'っ', 'ゃ', 'ゅ', 'ょ', 'ゎ', 'ー':
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNameParser_ParsePair(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name         string
+		inputName    parser.FullName
+		inputReading parser.FullName
+		want         [4]string
+		wantErrMsg   string
+	}
+
+	tests := []testdata{
+		{
+			name:         "カタカナの読み",
+			inputName:    "竈門炭治郎",
+			inputReading: "カマドタンジロウ",
+			want:         [4]string{"竈門", "炭治郎", "カマド", "タンジロウ"},
+		},
+		{
+			name:         "ひらがなの読み",
+			inputName:    "我妻善逸",
+			inputReading: "あがつまぜんいつ",
+			want:         [4]string{"我妻", "善逸", "あがつま", "ぜんいつ"},
+		},
+		{
+			name:         "名前のかなは読みと一致させる",
+			inputName:    "中山マサ",
+			inputReading: "なかやままさ",
+			want:         [4]string{"中山", "マサ", "なかやま", "まさ"},
+		},
+		{
+			name:         "読みが名前と合わない",
+			inputName:    "田中マサ",
+			inputReading: "たなかはなこ",
+			wantErrMsg:   "reading cannot be reconciled with the name: name=田中/マサ, reading=た/なかはなこ",
+		},
+		{
+			name:         "読みが短すぎる",
+			inputName:    "田中太郎",
+			inputReading: "た",
+			wantErrMsg:   "failed reading: parse error: name length needs at least 2 chars",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser("/", seimei.InitKanjiFeatureManager())
+			got, err := sut.ParsePair(tt.inputName, tt.inputReading)
+			if tt.wantErrMsg != "" {
+				if err == nil {
+					t.Fatal("happen no error")
+				}
+				if diff := cmp.Diff(err.Error(), tt.wantErrMsg); diff != "" {
+					t.Fatalf("failed to test on error. diff: %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			gotNames := [4]string{
+				string(got.Name.LastName), string(got.Name.FirstName), string(got.Reading.LastName), string(got.Reading.FirstName),
+			}
+			if diff := cmp.Diff(gotNames, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+			if got.Score <= 0 || got.Score > 1 {
+				t.Errorf("score is out of range: %v", got.Score)
+			}
+		})
+	}
+}
@@ -19,11 +19,18 @@ var (
 	ErrNameLength       = errors.New("name length needs at least 2 chars")
 	ErrSplitPosition    = errors.New("split position is invalid")
 	ErrParserNotWorking = errors.New("the name has an unexpected string, so the division failed")
+	ErrCandidateSize    = errors.New("candidate size is invalid")
 )
 
 type Parser interface {
 	Parse(fullname FullName, separator Separator) (DividedName, error)
 }
+
+// CandidatesParser is a Parser which can rank several divided names for one full name.
+type CandidatesParser interface {
+	Parser
+	ParseCandidates(fullname FullName, separator Separator, k int) ([]DividedName, error)
+}
 type FullName string
 
 type FirstName string
@@ -73,32 +80,65 @@ func (f FullName) Split(position int) (LastName, FirstName, error) {
 		return "", "", fmt.Errorf("%w: position(=%d) is over text length(=%d)", ErrSplitPosition, position, length)
 	}
 
-	return LastName([]rune(f)[:position]), FirstName([]rune(f)[position:]), nil
+	// The name is sliced at the byte offset of the position, so that splitting does not allocate.
+	offset := len(f)
+
+	for i := range string(f) {
+		if position == 0 {
+			offset = i
+
+			break
+		}
+		position--
+	}
+
+	return LastName(f[:offset]), FirstName(f[offset:]), nil
 }
 
 func (f FullName) Slice() []rune {
 	return []rune(f)
 }
 
+// MiddleName is the names between the given name and the family name, such as テレサ of "マリア・テレサ・ロペス".
+type MiddleName string
+
 type Separator string
 
 type NameParser struct {
 	Parsers   []Parser
 	Separator Separator
-}
-
-func NewNameParser(separatorString Separator, m feature.KanjiFeatureManager) NameParser {
-	s := make([]Parser, 0)
-	s = append(s, NewRuleBaseParser())
-	s = append(s, NewStatisticsParser(m))
+	// Normalizers rewrite the full name in order before it is validated and divided.
+	Normalizers []Normalizer
+	// MinScore is the lowest score accepted by Parse. Zero disables the check.
+	MinScore float64
+	// MinMargin is the lowest accepted difference between the best and the second-best score.
+	// Zero disables the check.
+	MinMargin float64
+}
+
+// NewNameParser returns the name parser trying DictionaryParser, LatinParser, ForeignParser, RuleBaseParser
+// and StatisticsParser in order, with KanaParser after DictionaryParser given WithKana or WithKanaModel.
+// The chain and the other fields can be changed by opts.
+func NewNameParser(separatorString Separator, m feature.KanjiFeatureManager, opts ...NameParserOption) NameParser {
+	//nolint:exhaustivestruct
+	c := nameParserConfig{}
+	for _, o := range opts {
+		o(&c)
+	}
 
 	return NameParser{
-		Parsers:   s,
-		Separator: separatorString,
+		Parsers:     c.chain(m),
+		Separator:   separatorString,
+		Normalizers: c.normalizers,
+		MinScore:    c.minScore,
+		MinMargin:   c.minMargin,
 	}
 }
 
 func (n NameParser) Parse(fullname FullName) (DividedName, error) {
+	original := fullname
+	fullname = n.normalize(fullname)
+
 	if err := n.validate(fullname); err != nil {
 		return DividedName{}, fmt.Errorf("parse error: %w", err)
 	}
@@ -110,6 +150,12 @@ func (n NameParser) Parse(fullname FullName) (DividedName, error) {
 		}
 
 		if !v.IsZero() {
+			v = n.withInput(v, original, fullname)
+
+			if err := n.checkConfidence(p, fullname, v); err != nil {
+				return DividedName{}, fmt.Errorf("parse error: %w", err)
+			}
+
 			return v, nil
 		}
 	}
@@ -118,6 +164,51 @@ func (n NameParser) Parse(fullname FullName) (DividedName, error) {
 	return DividedName{}, ErrParserNotWorking
 }
 
+// ParseCandidates returns at most k divided names ordered by descending score.
+// Parsers without ranking support contribute their single result.
+func (n NameParser) ParseCandidates(fullname FullName, k int) ([]DividedName, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("parse error: %w: k(=%d) must be positive", ErrCandidateSize, k)
+	}
+
+	original := fullname
+	fullname = n.normalize(fullname)
+
+	if err := n.validate(fullname); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	for _, p := range n.Parsers {
+		if cp, ok := p.(CandidatesParser); ok {
+			vs, err := cp.ParseCandidates(fullname, n.Separator, k)
+			if err != nil {
+				return nil, fmt.Errorf("parse error: %w", err)
+			}
+
+			if len(vs) > 0 {
+				for i, v := range vs {
+					vs[i] = n.withInput(v, original, fullname)
+				}
+
+				return vs, nil
+			}
+
+			continue
+		}
+
+		v, err := p.Parse(fullname, n.Separator)
+		if err != nil {
+			return nil, fmt.Errorf("parse error: %w", err)
+		}
+
+		if !v.IsZero() {
+			return []DividedName{n.withInput(v, original, fullname)}, nil
+		}
+	}
+
+	return nil, ErrParserNotWorking
+}
+
 func (n NameParser) validate(fullname FullName) error {
 	if fullname.Length() < minNameLength {
 		return ErrNameLength
@@ -129,13 +220,25 @@ func (n NameParser) validate(fullname FullName) error {
 type DividedName struct {
 	FirstName FirstName
 	LastName  LastName
-	Separator Separator
-	Score     float64
-	Algorithm Algorithm
-}
-
+	// MiddleName is empty for the names without middle names, which are all the names but foreign ones.
+	MiddleName MiddleName
+	Separator  Separator
+	Score      float64
+	Algorithm  Algorithm
+	// Original and Normalized are the input before and after the normalizers of NameParser.
+	// Both are empty when NameParser has no normalizers.
+	Original   FullName
+	Normalized FullName
+}
+
+// String joins the names by the separator with the family name first, as the names are given in reverse,
+// such as "ロペス テレサ マリア" of "マリア・テレサ・ロペス".
 func (n DividedName) String() string {
-	return string(n.LastName) + string(n.Separator) + string(n.FirstName)
+	if n.MiddleName == "" {
+		return string(n.LastName) + string(n.Separator) + string(n.FirstName)
+	}
+
+	return string(n.LastName) + string(n.Separator) + string(n.MiddleName) + string(n.Separator) + string(n.FirstName)
 }
 
 //nolint:exhaustivestruct
@@ -87,6 +87,52 @@ func TestNameParser_Parse(t *testing.T) {
 				Algorithm: parser.Statistics,
 			},
 		},
+		{
+			name:  "Taro Yamada",
+			input: "Taro Yamada",
+			want: parser.DividedName{
+				LastName:  "Yamada",
+				FirstName: "Taro",
+				Separator: separator,
+				Score:     0.8571428571428571,
+				Algorithm: parser.Latin,
+			},
+		},
+		{
+			name:  "John Ronald Tolkien",
+			input: "John Ronald Tolkien",
+			want: parser.DividedName{
+				LastName:   "Tolkien",
+				FirstName:  "John",
+				MiddleName: "Ronald",
+				Separator:  separator,
+				Score:      0.5,
+				Algorithm:  parser.Latin,
+			},
+		},
+		{
+			name:  "abc",
+			input: "abc",
+			want: parser.DividedName{
+				LastName:  "a",
+				FirstName: "bc",
+				Separator: separator,
+				Score:     0.3333333333333333,
+				Algorithm: parser.Statistics,
+			},
+		},
+		{
+			name:  "マリア・テレサ・ロペス",
+			input: "マリア・テレサ・ロペス",
+			want: parser.DividedName{
+				LastName:   "ロペス",
+				FirstName:  "マリア",
+				MiddleName: "テレサ",
+				Separator:  separator,
+				Score:      1,
+				Algorithm:  parser.Foreign,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,3 +283,82 @@ func TestFullName_Sprint(t *testing.T) {
 		})
 	}
 }
+
+func TestNameParser_ParseCandidates(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   parser.FullName
+		inputK  int
+		want    []parser.DividedName
+		wantErr error
+	}
+
+	separator := parser.Separator("/")
+
+	tests := []testdata{
+		{
+			name:   "ルールベースで分割できる場合は候補が1つ",
+			input:  "中山マサ",
+			inputK: 3,
+			want: []parser.DividedName{
+				{
+					LastName:  "中山",
+					FirstName: "マサ",
+					Separator: separator,
+					Score:     1,
+					Algorithm: parser.Rule,
+				},
+			},
+		},
+		{
+			name:   "統計量ベースで候補を並べる",
+			input:  "菅義偉",
+			inputK: 2,
+			want: []parser.DividedName{
+				{
+					LastName:  "菅",
+					FirstName: "義偉",
+					Separator: separator,
+					Score:     0.48027055739279506,
+					Algorithm: parser.Statistics,
+				},
+				{
+					LastName:  "菅義",
+					FirstName: "偉",
+					Separator: separator,
+					Score:     0.27858943555461146,
+					Algorithm: parser.Statistics,
+				},
+			},
+		},
+		{
+			name:    "1文字は分割できない",
+			input:   "あ",
+			inputK:  2,
+			wantErr: parser.ErrNameLength,
+		},
+		{
+			name:    "候補数が0はエラー",
+			input:   "菅義偉",
+			inputK:  0,
+			wantErr: parser.ErrCandidateSize,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewNameParser(separator, seimei.InitKanjiFeatureManager())
+			got, err := sut.ParseCandidates(tt.input, tt.inputK)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("divided names mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
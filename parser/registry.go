@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+)
+
+var ErrUnknownAlgorithm = errors.New("algorithm is not registered")
+
+// ParserFactory builds a Parser dividing names with the kanji feature table.
+type ParserFactory func(m feature.KanjiFeatureManager) Parser
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Algorithm]ParserFactory{
+		Foreign: func(feature.KanjiFeatureManager) Parser {
+			return NewForeignParser()
+		},
+		Kana: func(feature.KanjiFeatureManager) Parser {
+			return NewKanaParser(feature.DefaultKanaModel())
+		},
+		Latin: func(feature.KanjiFeatureManager) Parser {
+			return NewLatinParser(feature.DefaultLatinModel())
+		},
+		Rule: func(feature.KanjiFeatureManager) Parser {
+			return NewRuleBaseParser()
+		},
+		Statistics: func(m feature.KanjiFeatureManager) Parser {
+			return NewStatisticsParser(m)
+		},
+	}
+)
+
+// RegisterParser makes the parser built by f selectable by the algorithm a, such as the flag --algorithm.
+// It panics when a is already registered or f is nil.
+func RegisterParser(a Algorithm, f ParserFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if f == nil {
+		panic(fmt.Sprintf("parser: factory of %s is nil", a))
+	}
+
+	if _, ok := registry[a]; ok {
+		panic(fmt.Sprintf("parser: %s is registered twice", a))
+	}
+
+	registry[a] = f
+}
+
+// LookupParser returns the factory registered for the algorithm a.
+func LookupParser(a Algorithm) (ParserFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	f, ok := registry[a]
+
+	return f, ok
+}
+
+// Algorithms returns the registered algorithms in alphabetical order.
+func Algorithms() []Algorithm {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	as := make([]Algorithm, 0, len(registry))
+	for a := range registry {
+		as = append(as, a)
+	}
+
+	sort.Slice(as, func(i, j int) bool {
+		return as[i] < as[j]
+	})
+
+	return as
+}
+
+// NewParsers builds the parsers registered for as in the given order.
+func NewParsers(m feature.KanjiFeatureManager, as ...Algorithm) ([]Parser, error) {
+	ps := make([]Parser, 0, len(as))
+
+	for _, a := range as {
+		f, ok := LookupParser(a)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, a)
+		}
+
+		ps = append(ps, f(m))
+	}
+
+	return ps, nil
+}
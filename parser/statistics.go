@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/glassmonkey/seimei/v2/feature"
 )
@@ -10,40 +11,52 @@ const (
 	Statistics = Algorithm("statistics")
 )
 
-func NewStatisticsParser(m feature.KanjiFeatureManager) StatisticsParser {
-	return StatisticsParser{
+func NewStatisticsParser(m feature.KanjiFeatureManager, opts ...StatisticsOption) StatisticsParser {
+	x := m.Index
+	if x == nil {
+		v := feature.NewKanjiFeatureIndex(m)
+		x = &v
+	}
+
+	s := StatisticsParser{
 		OrderCalculator: feature.KanjiOrderFeatureCalculator{
 			Manager: m,
 		},
 		LengthCalculator: feature.KanjiLengthFeatureCalculator{
 			Manager: m,
 		},
+		Index: x,
+	}
+
+	for _, o := range opts {
+		o(&s)
 	}
+
+	return s
 }
 
 type StatisticsParser struct {
 	OrderCalculator  feature.KanjiOrderFeatureCalculator
 	LengthCalculator feature.KanjiLengthFeatureCalculator
+	// Index scores names without allocation. When it is nil, the calculators score names instead.
+	Index *feature.KanjiFeatureIndex
 }
 
-func (s StatisticsParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
-	ms := 0.0
-	mi := 1
-	features := feature.Features{}
+// maxStackNameLength is the longest name which Parse divides without allocation.
+const maxStackNameLength = 32
 
-	for i := range fullname.Slice() {
-		l, f, err := fullname.Split(i)
-		if err != nil {
-			return DividedName{}, fmt.Errorf("parse error: %w", err)
-		}
+func (s StatisticsParser) Parse(fullname FullName, separator Separator) (DividedName, error) {
+	var buf [maxStackNameLength]float64
 
-		cs, err := s.score(l, f)
-		if err != nil {
-			return DividedName{}, fmt.Errorf("parse error: %w", err)
-		}
+	features, err := s.appendScores(buf[:0], fullname)
+	if err != nil {
+		return DividedName{}, err
+	}
 
-		features = append(features, cs)
+	ms := 0.0
+	mi := 1
 
+	for i, cs := range features {
 		if cs > ms {
 			ms = cs
 			mi = i
@@ -59,11 +72,94 @@ func (s StatisticsParser) Parse(fullname FullName, separator Separator) (Divided
 		FirstName: f,
 		LastName:  l,
 		Separator: separator,
-		Score:     features.SoftMax()[mi],
+		Score:     features.SoftMaxAt(mi),
 		Algorithm: Statistics,
 	}, nil
 }
 
+// ParseCandidates returns at most k divided names ordered by descending probability.
+// Splits that leave the last name or the first name empty are not candidates.
+func (s StatisticsParser) ParseCandidates(fullname FullName, separator Separator, k int) ([]DividedName, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("%w: k(=%d) must be positive", ErrCandidateSize, k)
+	}
+
+	features, err := s.appendScores(nil, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilities := features.SoftMax()
+
+	positions := make([]int, 0, len(features))
+	for i := 1; i < len(features); i++ {
+		positions = append(positions, i)
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		return probabilities[positions[i]] > probabilities[positions[j]]
+	})
+
+	if len(positions) > k {
+		positions = positions[:k]
+	}
+
+	candidates := make([]DividedName, 0, len(positions))
+
+	for _, p := range positions {
+		l, f, err := fullname.Split(p)
+		if err != nil {
+			return nil, fmt.Errorf("parse error: %w", err)
+		}
+
+		candidates = append(candidates, DividedName{
+			FirstName: f,
+			LastName:  l,
+			Separator: separator,
+			Score:     probabilities[p],
+			Algorithm: Statistics,
+		})
+	}
+
+	return candidates, nil
+}
+
+// appendScores appends the raw score of each split position to dst, indexed by the length of the last name.
+func (s StatisticsParser) appendScores(dst feature.Features, fullname FullName) (feature.Features, error) {
+	if s.Index != nil {
+		var buf [maxStackNameLength]rune
+
+		cs := buf[:0]
+		for _, c := range string(fullname) {
+			cs = append(cs, c)
+		}
+
+		for i := range cs {
+			dst = append(dst, s.indexScore(cs, i))
+		}
+
+		return dst, nil
+	}
+
+	features := dst
+
+	for i := range fullname.Slice() {
+		l, f, err := fullname.Split(i)
+		if err != nil {
+			return nil, fmt.Errorf("parse error: %w", err)
+		}
+
+		cs, err := s.score(l, f)
+		if err != nil {
+			return nil, fmt.Errorf("parse error: %w", err)
+		}
+
+		features = append(features, cs)
+	}
+
+	return features, nil
+}
+
 const orderOnlyScoreLength = 4
 
 // Score referer: https://github.com/rskmoi/namedivider-python/blob/master/namedivider/name_divider.py#L206
@@ -80,7 +176,7 @@ func (s StatisticsParser) score(lastName LastName, firstName FirstName) (float64
 		return 0, fmt.Errorf("failed Order Score: %w", err)
 	}
 
-	os := (ols + ofs) / (float64(fullname.Length()) - minNameLength)
+	os := orderScore(fullname.Length(), ols, ofs)
 	// https://github.com/rskmoi/namedivider-python/blob/d87a488d4696bc26d2f6444ed399d83a6a1911a7/namedivider/name_divider.py#L219
 	if fullname.Length() == orderOnlyScoreLength {
 		return os, nil
@@ -96,7 +192,52 @@ func (s StatisticsParser) score(lastName LastName, firstName FirstName) (float64
 		return 0, fmt.Errorf("failed Length Score: %w", err)
 	}
 
-	ls := (lls + lfs) / float64(fullname.Length())
+	ls := lengthScore(fullname.Length(), lls, lfs)
 
 	return (os + ls) / 2, nil
 }
+
+// indexScore is score with Index for the split of cs at position.
+func (s StatisticsParser) indexScore(cs []rune, position int) float64 {
+	n := len(cs)
+
+	ols, ofs := 0.0, 0.0
+	for i := 0; i < position; i++ {
+		ols += s.Index.OrderValue(cs[i], n, i, feature.OrderPosition(true, position, i))
+	}
+
+	for i := position; i < n; i++ {
+		ofs += s.Index.OrderValue(cs[i], n, i, feature.OrderPosition(false, n-position, i-position))
+	}
+
+	os := orderScore(n, ols, ofs)
+	if n == orderOnlyScoreLength {
+		return os
+	}
+
+	lls, lfs := 0.0, 0.0
+	for i := 0; i < position; i++ {
+		lls += s.Index.LengthValue(cs[i], n, i, feature.LengthPosition(true, position))
+	}
+
+	for i := position; i < n; i++ {
+		lfs += s.Index.LengthValue(cs[i], n, i, feature.LengthPosition(false, n-position))
+	}
+
+	ls := lengthScore(n, lls, lfs)
+
+	return (os + ls) / 2
+}
+
+// orderScore is 0 for a full name of 2 characters, which has no character in the middle to have an order value.
+func orderScore(fullNameLength int, lastNameScore, firstNameScore float64) float64 {
+	if fullNameLength <= minNameLength {
+		return 0
+	}
+
+	return (lastNameScore + firstNameScore) / (float64(fullNameLength) - minNameLength)
+}
+
+func lengthScore(fullNameLength int, lastNameScore, firstNameScore float64) float64 {
+	return (lastNameScore + firstNameScore) / float64(fullNameLength)
+}
@@ -1,6 +1,9 @@
 package parser_test
 
 import (
+	"errors"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/glassmonkey/seimei/v2"
@@ -19,6 +22,17 @@ func TestStatisticsParser_Parse(t *testing.T) {
 
 	separator := parser.Separator("/")
 	tests := []testdata{
+		{
+			name:  "2文字",
+			input: "乙一",
+			want: parser.DividedName{
+				LastName:  "乙",
+				FirstName: "一",
+				Separator: separator,
+				Score:     0.5621765008857981,
+				Algorithm: parser.Statistics,
+			},
+		},
 		{
 			name:  "3文字",
 			input: "菅義偉",
@@ -81,3 +95,191 @@ func TestStatisticsParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestStatisticsParser_ParseCandidates(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name    string
+		input   parser.FullName
+		inputK  int
+		want    []parser.DividedName
+		wantErr error
+	}
+
+	separator := parser.Separator("/")
+
+	tests := []testdata{
+		{
+			name:   "確率の高い順に並ぶ",
+			input:  "中曽根康弘",
+			inputK: 3,
+			want: []parser.DividedName{
+				{
+					LastName:  "中曽根",
+					FirstName: "康弘",
+					Separator: separator,
+					Score:     0.3127240879300895,
+					Algorithm: parser.Statistics,
+				},
+				{
+					LastName:  "中曽",
+					FirstName: "根康弘",
+					Separator: separator,
+					Score:     0.20732917245030127,
+					Algorithm: parser.Statistics,
+				},
+				{
+					LastName:  "中曽根康",
+					FirstName: "弘",
+					Separator: separator,
+					Score:     0.16786992123807729,
+					Algorithm: parser.Statistics,
+				},
+			},
+		},
+		{
+			name:   "候補数より分割位置が少ない",
+			input:  "菅義偉",
+			inputK: 5,
+			want: []parser.DividedName{
+				{
+					LastName:  "菅",
+					FirstName: "義偉",
+					Separator: separator,
+					Score:     0.48027055739279506,
+					Algorithm: parser.Statistics,
+				},
+				{
+					LastName:  "菅義",
+					FirstName: "偉",
+					Separator: separator,
+					Score:     0.27858943555461146,
+					Algorithm: parser.Statistics,
+				},
+			},
+		},
+		{
+			name:   "同点の場合は名字が短い順",
+			input:  "やまだはなこ",
+			inputK: 2,
+			want: []parser.DividedName{
+				{
+					LastName:  "や",
+					FirstName: "まだはなこ",
+					Separator: separator,
+					Score:     0.16666666666666666,
+					Algorithm: parser.Statistics,
+				},
+				{
+					LastName:  "やま",
+					FirstName: "だはなこ",
+					Separator: separator,
+					Score:     0.16666666666666666,
+					Algorithm: parser.Statistics,
+				},
+			},
+		},
+		{
+			name:    "候補数が0はエラー",
+			input:   "菅義偉",
+			inputK:  0,
+			wantErr: parser.ErrCandidateSize,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sut := parser.NewStatisticsParser(seimei.InitKanjiFeatureManager())
+			got, err := sut.ParseCandidates(tt.input, separator, tt.inputK)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error is not expected, got error=(%v), want error=(%v)", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("divided names mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStatisticsParser_Index(t *testing.T) {
+	t.Parallel()
+
+	// generate from https://testdata.userlocal.jp
+	b, err := os.ReadFile("../benchmark/sample.csv")
+	if err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	m := seimei.InitKanjiFeatureManager()
+	sut := parser.NewStatisticsParser(m)
+	calculators := parser.StatisticsParser{
+		OrderCalculator:  sut.OrderCalculator,
+		LengthCalculator: sut.LengthCalculator,
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		input := parser.FullName(strings.ReplaceAll(line, " ", ""))
+		if input.Length() < 3 {
+			continue
+		}
+
+		got, err := sut.Parse(input, " ")
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+
+		want, err := calculators.Parse(input, " ")
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("%s: value mismatch (-got +want):\n%s", input, diff)
+		}
+	}
+}
+
+// AllocsPerRun must not run in parallel with other tests.
+// nolint: paralleltest
+func TestStatisticsParser_ParseAllocs(t *testing.T) {
+	sut := parser.NewStatisticsParser(seimei.InitKanjiFeatureManager())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := sut.Parse("竈門炭治郎", " "); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("failed to test. allocs per run: %v", allocs)
+	}
+}
+
+func BenchmarkStatisticsParser_Parse(b *testing.B) {
+	sut := parser.NewStatisticsParser(seimei.InitKanjiFeatureManager())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := sut.Parse("竈門炭治郎", " "); err != nil {
+			b.Fatalf("happen error: %v", err)
+		}
+	}
+}
+
+func BenchmarkStatisticsParser_ParseCalculators(b *testing.B) {
+	sut := parser.NewStatisticsParser(seimei.InitKanjiFeatureManager())
+	sut.Index = nil
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := sut.Parse("竈門炭治郎", " "); err != nil {
+			b.Fatalf("happen error: %v", err)
+		}
+	}
+}
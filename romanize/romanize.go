@@ -0,0 +1,362 @@
+// Package romanize writes divided kana names in the Latin alphabet with modified Hepburn, such as "YAMADA Hanako".
+package romanize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+// LongVowel is how long vowels, such as おう of "ようこ" and ー, are written.
+type LongVowel string
+
+const (
+	// Omit writes long vowels as short ones, such as "Yoko", as passports do.
+	Omit = LongVowel("omit")
+	// Macron writes long vowels with the macron, such as "Yōko".
+	Macron = LongVowel("macron")
+	// Keep writes long vowels as they are spelled in kana, such as "Youko".
+	Keep = LongVowel("keep")
+)
+
+// Order is the order of the family name and the given name.
+type Order string
+
+const (
+	// FamilyGiven writes the family name first, such as "YAMADA Hanako".
+	FamilyGiven = Order("family-given")
+	// GivenFamily writes the given name first, such as "Hanako YAMADA".
+	GivenFamily = Order("given-family")
+)
+
+var (
+	ErrNotKana          = errors.New("name must consist of kana to be romanized")
+	ErrUnromanizable    = errors.New("kana has no romanisation")
+	ErrUnknownLongVowel = errors.New("long vowel must be one of omit, macron and keep")
+	ErrUnknownOrder     = errors.New("order must be one of family-given and given-family")
+)
+
+// ParseLongVowel selects the long vowel style by its name, such as the flag --long-vowel.
+func ParseLongVowel(s string) (LongVowel, error) {
+	for _, v := range []LongVowel{Omit, Macron, Keep} {
+		if string(v) == s {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownLongVowel, s)
+}
+
+// ParseOrder selects the order by its name, such as the flag --name-order.
+func ParseOrder(s string) (Order, error) {
+	for _, o := range []Order{FamilyGiven, GivenFamily} {
+		if string(o) == s {
+			return o, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownOrder, s)
+}
+
+// Romanizer writes kana in the Latin alphabet.
+type Romanizer struct {
+	LongVowel LongVowel
+	Order     Order
+	// UpperFamily writes the family name in capitals, such as "YAMADA", to tell it from the given name.
+	UpperFamily bool
+}
+
+// Option changes the romanizer built by NewRomanizer.
+type Option func(*Romanizer)
+
+// NewRomanizer returns the romanizer writing names as passports do, such as "YAMADA Yoko", which opts can change.
+func NewRomanizer(opts ...Option) Romanizer {
+	r := Romanizer{
+		LongVowel:   Omit,
+		Order:       FamilyGiven,
+		UpperFamily: true,
+	}
+	for _, o := range opts {
+		o(&r)
+	}
+
+	return r
+}
+
+// WithLongVowel writes long vowels in the style v.
+func WithLongVowel(v LongVowel) Option {
+	return func(r *Romanizer) {
+		r.LongVowel = v
+	}
+}
+
+// WithOrder writes the family name and the given name in the order o.
+func WithOrder(o Order) Option {
+	return func(r *Romanizer) {
+		r.Order = o
+	}
+}
+
+// WithoutUpperFamily capitalises only the first letter of the family name, such as "Yamada Hanako".
+func WithoutUpperFamily() Option {
+	return func(r *Romanizer) {
+		r.UpperFamily = false
+	}
+}
+
+// RomanizeName writes the divided kana name, such as やまだ/はなこ, as "YAMADA Hanako".
+// The middle names follow the given name, and the names delimited as foreign names are, such as "ダ＝ヴィンチ",
+// are written as separate words.
+func (r Romanizer) RomanizeName(n parser.DividedName) (string, error) {
+	family, err := r.romanizeWords(string(n.LastName))
+	if err != nil {
+		return "", err
+	}
+
+	given, err := r.romanizeWords(string(n.FirstName))
+	if err != nil {
+		return "", err
+	}
+
+	var middle []string
+	if n.MiddleName != "" {
+		if middle, err = r.romanizeWords(string(n.MiddleName)); err != nil {
+			return "", err
+		}
+	}
+
+	for i, w := range family {
+		if r.UpperFamily {
+			family[i] = strings.ToUpper(w)
+		} else {
+			family[i] = capitalize(w)
+		}
+	}
+
+	for _, ws := range [][]string{given, middle} {
+		for i, w := range ws {
+			ws[i] = capitalize(w)
+		}
+	}
+
+	words := make([]string, 0, len(family)+len(given)+len(middle))
+	if r.Order == GivenFamily {
+		words = append(append(append(words, given...), middle...), family...)
+	} else {
+		words = append(append(append(words, family...), given...), middle...)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// romanizeWords writes each name between the delimiters of foreign names in lower case.
+func (r Romanizer) romanizeWords(s string) ([]string, error) {
+	pieces := strings.FieldsFunc(s, func(c rune) bool {
+		return strings.ContainsRune(string(parser.DefaultForeignDelimiters()), c)
+	})
+	if len(pieces) == 0 {
+		pieces = []string{s}
+	}
+
+	words := make([]string, 0, len(pieces))
+
+	for _, piece := range pieces {
+		w, err := r.Romanize(piece)
+		if err != nil {
+			return nil, err
+		}
+
+		words = append(words, w)
+	}
+
+	return words, nil
+}
+
+// Romanize writes the kana s in lower case, such as "hanako".
+// ん is written as n, followed by an apostrophe before a vowel or y, such as "jun'ichi".
+// っ doubles the following consonant, such as "hotta", and is written as t before ch, such as "matcha".
+// A vowel lengthens the same vowel before it, or o before う as well, unless a vowel follows,
+// since such as いのうえ is read as "Inoue".
+// Kana without romanisation, such as ヷ and ゝ at the beginning, is ErrUnromanizable.
+func (r Romanizer) Romanize(s string) (string, error) {
+	if !feature.IsKanaName(s) {
+		return "", fmt.Errorf("%w: %s", ErrNotKana, s)
+	}
+
+	kana := make([]rune, 0, utf8.RuneCountInString(s))
+	for _, c := range s {
+		kana = append(kana, feature.FoldKana(c))
+	}
+
+	var (
+		b strings.Builder
+		// last is the kana of the previous syllable and written is its romanisation.
+		last    []rune
+		written string
+		// double is whether っ precedes the current syllable.
+		double bool
+	)
+
+	for i := 0; i < len(kana); {
+		switch kana[i] {
+		case 'っ':
+			double = true
+			i++
+
+			continue
+		case 'ー':
+			r.lengthen(&b, written, "")
+			i++
+
+			continue
+		case 'ん':
+			b.WriteString("n")
+
+			if next, _ := lookup(kana[i+1:]); next != "" && strings.ContainsRune("aiueoy", rune(next[0])) {
+				b.WriteString("'")
+			}
+
+			last, written = kana[i:i+1], "n"
+			i++
+
+			continue
+		}
+
+		current, size := lookup(kana[i:])
+		// The iteration marks repeat the previous syllable, which is voiced by ゞ.
+		if kana[i] == 'ゝ' || kana[i] == 'ゞ' {
+			current, size = repeat(last, kana[i] == 'ゞ')
+		}
+
+		if current == "" {
+			return "", fmt.Errorf("%w: %c of %s", ErrUnromanizable, []rune(s)[i], s)
+		}
+
+		next, _ := lookup(kana[i+size:])
+
+		if isLong(written, current, next) {
+			r.lengthen(&b, written, current)
+		} else {
+			if double {
+				b.WriteString(doubled(current))
+			}
+
+			b.WriteString(current)
+		}
+
+		double = false
+		last, written = kana[i:i+size], current
+		i += size
+	}
+
+	return b.String(), nil
+}
+
+// lookup returns the romanisation of the syllable at the beginning of kana and the number of kana it consists of.
+func lookup(kana []rune) (string, int) {
+	if len(kana) == 0 {
+		return "", 0
+	}
+
+	if len(kana) > 1 {
+		if v, ok := syllables[string(kana[:2])]; ok {
+			return v, 2
+		}
+	}
+
+	return syllables[string(kana[:1])], 1
+}
+
+// repeat returns the romanisation of the syllable last, voiced such as "gi" of き when voiced is true.
+func repeat(last []rune, voiced bool) (string, int) {
+	if len(last) == 0 {
+		return "", 1
+	}
+
+	if voiced {
+		// The voiced kana follows its unvoiced one, such as が after か.
+		c := []rune{last[0] + 1}
+		if v, ok := syllables[string(append(c, last[1:]...))]; ok && strings.ContainsRune(voicedKana, c[0]) {
+			return v, 1
+		}
+	}
+
+	return syllables[string(last)], 1
+}
+
+const voicedKana = "がぎぐげござじずぜぞだぢづでどばびぶべぼ"
+
+// isLong reports whether the vowel syllable current lengthens the last one.
+func isLong(last, current, next string) bool {
+	if last == "" || len(current) != 1 || !isVowel(current[0]) {
+		return false
+	}
+
+	if next != "" && isVowel(next[0]) {
+		return false
+	}
+
+	v := last[len(last)-1]
+
+	return (v == current[0] && v != 'i') || (v == 'o' && current[0] == 'u')
+}
+
+// lengthen writes the long vowel of the last syllable, which is already written to b.
+// spelled is the vowel kana lengthening it, or empty for ー.
+func (r Romanizer) lengthen(b *strings.Builder, last, spelled string) {
+	if last == "" || !isVowel(last[len(last)-1]) {
+		return
+	}
+
+	v := last[len(last)-1]
+
+	switch r.LongVowel {
+	case Omit:
+	case Macron:
+		s := b.String()
+		if !strings.HasSuffix(s, string(v)) {
+			return
+		}
+
+		b.Reset()
+		b.WriteString(s[:len(s)-1])
+		b.WriteString(macrons[v])
+	case Keep:
+		if spelled == "" {
+			spelled = string(v)
+		}
+
+		b.WriteString(spelled)
+	}
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+// doubled returns the consonant written for っ before the syllable.
+func doubled(syllable string) string {
+	if syllable == "" || isVowel(syllable[0]) {
+		return ""
+	}
+
+	if strings.HasPrefix(syllable, "ch") {
+		return "t"
+	}
+
+	return syllable[:1]
+}
+
+func capitalize(s string) string {
+	c, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+
+	return string(unicode.ToUpper(c)) + s[size:]
+}
@@ -0,0 +1,124 @@
+package romanize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+	"github.com/glassmonkey/seimei/v2/romanize"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRomanizer_Romanize(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name       string
+		input      string
+		wantOmit   string
+		wantMacron string
+		wantKeep   string
+	}
+
+	tests := []testdata{
+		{name: "おうの長音", input: "ようこ", wantOmit: "yoko", wantMacron: "yōko", wantKeep: "youko"},
+		{name: "おおの長音", input: "おおの", wantOmit: "ono", wantMacron: "ōno", wantKeep: "oono"},
+		{name: "ううの長音", input: "ゆうき", wantOmit: "yuki", wantMacron: "yūki", wantKeep: "yuuki"},
+		{name: "母音が続くと長音にしない", input: "いのうえ", wantOmit: "inoue", wantMacron: "inoue", wantKeep: "inoue"},
+		{name: "いいは長音にしない", input: "いいだ", wantOmit: "iida", wantMacron: "iida", wantKeep: "iida"},
+		{name: "長音符", input: "ルーシー", wantOmit: "rushi", wantMacron: "rūshī", wantKeep: "ruushii"},
+		{name: "拗音", input: "きょうこ", wantOmit: "kyoko", wantMacron: "kyōko", wantKeep: "kyouko"},
+		{name: "撥音の後の母音", input: "じゅんいち", wantOmit: "jun'ichi", wantMacron: "jun'ichi", wantKeep: "jun'ichi"},
+		{name: "促音", input: "ほった", wantOmit: "hotta", wantMacron: "hotta", wantKeep: "hotta"},
+		{name: "chの前の促音", input: "まっちゃ", wantOmit: "matcha", wantMacron: "matcha", wantKeep: "matcha"},
+		{name: "踊り字", input: "すゞき", wantOmit: "suzuki", wantMacron: "suzuki", wantKeep: "suzuki"},
+		{name: "外来語の音", input: "ヴィクトリア", wantOmit: "vikutoria", wantMacron: "vikutoria", wantKeep: "vikutoria"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for v, want := range map[romanize.LongVowel]string{
+				romanize.Omit:   tt.wantOmit,
+				romanize.Macron: tt.wantMacron,
+				romanize.Keep:   tt.wantKeep,
+			} {
+				got, err := romanize.NewRomanizer(romanize.WithLongVowel(v)).Romanize(tt.input)
+				if err != nil {
+					t.Fatalf("happen error: %v", err)
+				}
+				if diff := cmp.Diff(got, want); diff != "" {
+					t.Errorf("value mismatch %s (-got +want):\n%s", v, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRomanizer_RomanizeName(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name      string
+		inputOpts []romanize.Option
+		inputName parser.DividedName
+		want      string
+		wantErr   error
+	}
+
+	tests := []testdata{
+		{
+			name:      "姓名の順で姓を大文字",
+			inputName: parser.DividedName{LastName: "やまだ", FirstName: "はなこ"},
+			want:      "YAMADA Hanako",
+		},
+		{
+			name:      "名姓の順",
+			inputOpts: []romanize.Option{romanize.WithOrder(romanize.GivenFamily)},
+			inputName: parser.DividedName{LastName: "ヤマダ", FirstName: "ハナコ"},
+			want:      "Hanako YAMADA",
+		},
+		{
+			name:      "長音記号と姓の先頭だけ大文字",
+			inputOpts: []romanize.Option{romanize.WithLongVowel(romanize.Macron), romanize.WithoutUpperFamily()},
+			inputName: parser.DividedName{LastName: "おおの", FirstName: "ようこ"},
+			want:      "Ōno Yōko",
+		},
+		{
+			name:      "外国人名のミドルネームと姓の前置詞",
+			inputOpts: []romanize.Option{romanize.WithOrder(romanize.GivenFamily)},
+			inputName: parser.DividedName{LastName: "ダ＝ヴィンチ", FirstName: "レオナルド", MiddleName: "マリア"},
+			want:      "Reonarudo Maria DA VINCHI",
+		},
+		{
+			name:      "漢字は扱わない",
+			inputName: parser.DividedName{LastName: "山田", FirstName: "はなこ"},
+			wantErr:   romanize.ErrNotKana,
+		},
+		{
+			name:      "先頭の踊り字は書けない",
+			inputName: parser.DividedName{LastName: "すずき", FirstName: "ゝこ"},
+			wantErr:   romanize.ErrUnromanizable,
+		},
+		{
+			name:      "ヷは書けない",
+			inputName: parser.DividedName{LastName: "ヷタナベ", FirstName: "ハナコ"},
+			wantErr:   romanize.ErrUnromanizable,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := romanize.NewRomanizer(tt.inputOpts...).RomanizeName(tt.inputName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error mismatch: got=%v, want=%v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("value mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package seimei
 
 import (
+	"context"
 	// Using embed.
 	_ "embed"
 	"encoding/csv"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/glassmonkey/seimei/v2/feature"
 	"github.com/glassmonkey/seimei/v2/parser"
@@ -19,106 +21,419 @@ type (
 	Name        string
 	ParseString string
 	Path        string
+	Top         int
 )
 
+// Stdin is the path which means the standard input.
+const Stdin Path = "-"
+
 //go:embed namedivider-python/assets/kanji.csv
 var assets string
 
-func InitNameParser(parseString ParseString, manager feature.KanjiFeatureManager) parser.NameParser {
-	return parser.NewNameParser(parser.Separator(parseString), manager)
+func InitNameParser(parseString ParseString, manager feature.KanjiFeatureManager, opts ...parser.NameParserOption) parser.NameParser {
+	return parser.NewNameParser(parser.Separator(parseString), manager, opts...)
 }
 
+var (
+	defaultManager     feature.KanjiFeatureManager
+	defaultManagerOnce sync.Once
+)
+
+// InitKanjiFeatureManager returns the embedded kanji feature table.
+// The table is loaded on the first call and shared by every caller afterwards, so it must not be modified.
 func InitKanjiFeatureManager() feature.KanjiFeatureManager {
-	r := csv.NewReader(strings.NewReader(assets))
-	m := make(map[feature.Character]feature.KanjiFeature)
+	defaultManagerOnce.Do(func() {
+		m, err := LoadKanjiFeatureManager(strings.NewReader(assets))
+		// since the embedded table is valid, it raise panic without returning an error.
+		if err != nil {
+			panic(err)
+		}
 
-	for i := 0; ; i++ {
-		record, err := r.Read()
-		if errors.Is(err, io.EOF) {
-			break
+		defaultManager = m
+	})
+
+	return defaultManager
+}
+
+// LoadKanaModel loads a kana feature table written by TrainKana.
+// The returned error lists every invalid line.
+func LoadKanaModel(r io.Reader) (feature.KanaModel, error) {
+	m, err := feature.ReadKanaFeatureCSV(r)
+	if err != nil {
+		return feature.KanaModel{}, fmt.Errorf("invalid kana feature table: %w", err)
+	}
+
+	return m, nil
+}
+
+// LoadLatinModel loads a latin feature table written by TrainLatin.
+// The returned error lists every invalid line.
+func LoadLatinModel(r io.Reader) (feature.LatinModel, error) {
+	m, err := feature.ReadLatinFeatureCSV(r)
+	if err != nil {
+		return feature.LatinModel{}, fmt.Errorf("invalid latin feature table: %w", err)
+	}
+
+	return m, nil
+}
+
+// LoadKanjiFeatureManager loads a kanji feature table in the same format as the embedded one.
+// The returned error lists every invalid line.
+func LoadKanjiFeatureManager(r io.Reader) (feature.KanjiFeatureManager, error) {
+	m, err := feature.ReadKanjiFeatureCSV(r)
+	if err != nil {
+		return feature.KanjiFeatureManager{}, fmt.Errorf("invalid kanji feature table: %w", err)
+	}
+
+	return m, nil
+}
+
+// InitReader returns the CSV reader of the file at path.
+//
+// Deprecated: The file is never closed. Open the file with os.Open and read it with csv.NewReader instead.
+func InitReader(path Path) (*csv.Reader, error) {
+	f, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return csv.NewReader(f), nil
+}
+
+// openFile opens the file at path, which the caller closes.
+func openFile(path Path) (*os.File, error) {
+	f, err := os.Open(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("fatal error file load: %w", err)
+	}
+
+	return f, nil
+}
+
+func ParseName(out, stderr io.Writer, fullname Name, parseString ParseString, opts ...Option) error {
+	cfg := newConfig(opts)
+	p := cfg.nameParser(parseString)
+
+	w := newResultWriter(out, stderr, cfg.format, cfg.romanizer != nil)
+
+	name, err := p.Parse(parser.FullName(fullname))
+
+	var romanized string
+	if err == nil {
+		romanized, err = cfg.romanize(name, parser.DividedName{})
+	}
+
+	if err := w.write(division{line: 0, input: string(fullname), name: name, romanized: romanized, err: err}); err != nil {
+		return err
+	}
+
+	return w.flush()
+}
+
+func ParseCandidates(out, stderr io.Writer, fullname Name, parseString ParseString, top Top, opts ...Option) error {
+	cfg := newConfig(opts)
+	p := cfg.nameParser(parseString)
+
+	names, err := p.ParseCandidates(parser.FullName(fullname), int(top))
+	if err != nil {
+		_, err := fmt.Fprintf(stderr, "%s\n", err.Error())
+		if err != nil {
+			return fmt.Errorf("happen error write stderr: %w", err)
 		}
 
+		return nil
+	}
+
+	for _, name := range names {
+		_, err = fmt.Fprintf(out, "%s\t%s\n", name.String(), formatScore(name.Score))
 		if err != nil {
-			panic(err)
+			return fmt.Errorf("happen error write stdout: %w", err)
 		}
+	}
 
-		if i == 0 {
-			continue
+	return nil
+}
+
+func ExplainName(out, stderr io.Writer, fullname Name, parseString ParseString, opts ...Option) error {
+	cfg := newConfig(opts)
+	p := cfg.nameParser(parseString)
+
+	e, err := p.Explain(parser.FullName(fullname))
+	if err != nil {
+		_, err := fmt.Fprintf(stderr, "%s\n", err.Error())
+		if err != nil {
+			return fmt.Errorf("happen error write stderr: %w", err)
 		}
 
-		c := feature.Character(record[0])
+		return nil
+	}
+
+	if err := writeExplanation(out, e); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
+
+func writeExplanation(out io.Writer, e parser.Explanation) error {
+	_, err := fmt.Fprintf(out, "%s\t%s\t%s\n", e.Result.String(), e.Result.Algorithm, formatScore(e.Result.Score))
+	if err != nil {
+		return err
+	}
+
+	if e.Result.Algorithm != parser.Statistics {
+		_, err := fmt.Fprintf(out, "the %s parser gives no explanation\n", e.Result.Algorithm)
 
-		var os, ls []float64
+		return err
+	}
 
-		for _, o := range record[feature.CharacterFeatureSize : feature.CharacterFeatureSize+feature.OrderFeatureSize] {
-			s, err := strconv.ParseFloat(o, 64)
+	for _, s := range e.Splits {
+		_, err := fmt.Fprintf(out, "split\t%s\tprobability=%s\tscore=%s\torder=%s\tlength=%s\n",
+			s.Name.String(), formatScore(s.Name.Score), formatScore(s.Score), formatScore(s.OrderScore), formatScore(s.LengthScore))
+		if err != nil {
+			return err
+		}
+
+		for _, c := range s.Characters {
+			part := "first"
+			if c.IsLastName {
+				part = "last"
+			}
+
+			_, err := fmt.Fprintf(out, "\t%s\t%s\torder=%s\torder_mask=%s\tlength=%s\tlength_mask=%s\tdefault=%t\n",
+				c.Character, part, formatScore(c.Order), formatMask(c.OrderMask), formatScore(c.Length), formatMask(c.LengthMask), c.IsDefault)
 			if err != nil {
-				panic(err)
+				return err
 			}
+		}
+	}
+
+	return nil
+}
+
+func formatMask(mask feature.Features) string {
+	if mask == nil {
+		return "-"
+	}
+
+	return fmt.Sprint([]float64(mask))
+}
+
+func ParseFile(out, stderr io.Writer, path Path, parseString ParseString, opts ...Option) error {
+	f, err := openFile(path)
+	if err != nil {
+		return fmt.Errorf("happen error load file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseReader(out, stderr, f, parseString, opts...)
+}
+
+// ParseReader divides the full names read from in, such as os.Stdin, in the same way as ParseFile.
+// Each result is written as soon as its line is read, so it can be used as a filter in a pipeline.
+func ParseReader(out, stderr io.Writer, in io.Reader, parseString ParseString, opts ...Option) error {
+	cfg := newConfig(opts)
+	p := cfg.nameParser(parseString)
+
+	if cfg.reading != "" && cfg.column == "" {
+		cfg.column = strconv.Itoa(defaultNameColumnIndex)
+	}
+
+	index, err := resolveColumn(cfg.column, nil)
+	if err != nil && !cfg.header {
+		return fmt.Errorf("happen error select column: %w", err)
+	}
+
+	readingIndex, err := resolveColumn(cfg.reading, nil)
+	if err != nil && !cfg.header {
+		return fmt.Errorf("happen error select reading column: %w", err)
+	}
+
+	var (
+		w  resultWriter
+		cw *columnResultWriter
+	)
 
-			os = append(os, s)
+	// The pairs are written as Result in the JSON formats, which have the fields of the reading.
+	if cfg.column != "" && (cfg.reading == "" || (cfg.format != JSONFormat && cfg.format != JSONLFormat)) {
+		cw, err = newColumnResultWriter(out, stderr, cfg.format, cfg.insert)
+		if err != nil {
+			return err
 		}
 
-		for _, l := range record[feature.CharacterFeatureSize+feature.OrderFeatureSize : feature.CharacterFeatureSize+feature.OrderFeatureSize+feature.LengthFeatureSize] {
-			s, err := strconv.ParseFloat(l, 64)
-			if err != nil {
-				panic(err)
+		cw.index = index
+		cw.reading = cfg.reading != ""
+		cw.romanized = cfg.romanizer != nil
+		w = cw
+	} else {
+		w = newResultWriter(out, stderr, cfg.format, cfg.romanizer != nil)
+	}
+
+	var reject *csv.Writer
+	if cfg.reject != nil {
+		reject = csv.NewWriter(cfg.reject)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		divider nameDivider = p
+		cache   *parser.CachedNameParser
+	)
+
+	if cfg.cacheSize > 0 && cfg.reading == "" {
+		cache = parser.NewCachedNameParser(p, cfg.cacheSize)
+		divider = cache
+	}
+
+	divide := divideRow(divider)
+	if cfg.reading != "" {
+		divide = dividePairRow(p)
+	}
+
+	if cfg.romanizer != nil {
+		divide = romanizeRow(cfg, divide)
+	}
+
+	rows := readRows(ctx, csv.NewReader(in), cfg, index, readingIndex)
+
+	for d := range parser.OrderedParallel(ctx, rows, parser.BatchOptions{Workers: cfg.workers}, divide) {
+		if d.row.fatal != nil {
+			return d.row.fatal
+		}
+
+		if d.row.skip != "" {
+			fmt.Fprintf(stderr, "%s\n", d.row.skip)
+			continue
+		}
+
+		if d.row.header {
+			if cw != nil {
+				cw.index = d.row.index
+
+				if err := cw.writeHeader(d.row.record); err != nil {
+					return err
+				}
 			}
 
-			ls = append(ls, s)
+			continue
+		}
+
+		var lc parser.ErrLowConfidence
+		if reject != nil && errors.As(d.err, &lc) {
+			if err := reject.Write([]string{d.row.input, lc.Best.String(), formatScore(lc.Best.Score), formatScore(lc.Margin)}); err != nil {
+				return fmt.Errorf("happen error write reject: %w", err)
+			}
+			reject.Flush()
+			continue
 		}
 
-		kf, err := feature.NewKanjiFeature(c, os, ls)
+		err := w.write(division{
+			line:      d.row.line,
+			input:     d.row.input,
+			record:    d.row.record,
+			name:      d.name,
+			reading:   d.reading,
+			romanized: d.romanized,
+			err:       d.err,
+		})
 		if err != nil {
-			panic(err)
+			return err
 		}
+	}
 
-		m[c] = kf
+	if err := w.flush(); err != nil {
+		return err
 	}
 
-	return feature.KanjiFeatureManager{
-		KanjiFeatureMap: m,
+	if reject != nil {
+		reject.Flush()
+
+		if err := reject.Error(); err != nil {
+			return fmt.Errorf("happen error write reject: %w", err)
+		}
 	}
+
+	if cache != nil {
+		s := cache.Stats()
+		fmt.Fprintf(stderr, "cache hits=%d misses=%d\n", s.Hits, s.Misses)
+	}
+
+	return nil
 }
 
-func InitReader(path Path) (*csv.Reader, error) {
-	f, err := os.Open(string(path))
+func formatScore(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// Train counts the divided names in the file, in which each line is a last name and a first name
+// joined by parseString, and writes the kanji feature table as CSV.
+func Train(out, stderr io.Writer, path Path, parseString ParseString) error {
+	counter := feature.NewKanjiFeatureCounter()
+
+	err := readDividedNames(stderr, path, parseString, func(_ int, l parser.LastName, f parser.FirstName) error {
+		return counter.Add(l, f)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("fatal error file load: %w", err)
+		return err
 	}
 
-	return csv.NewReader(f), nil
+	if err := feature.WriteKanjiFeatureCSV(out, counter.Manager()); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
 }
 
-func ParseName(out, stderr io.Writer, fullname Name, parseString ParseString) error {
-	m := InitKanjiFeatureManager()
-	p := InitNameParser(parseString, m)
+// TrainKana builds the kana feature table from the divided kana names in the file.
+// The names which are not kana only are reported to stderr and not counted.
+func TrainKana(out, stderr io.Writer, path Path, parseString ParseString) error {
+	m := feature.NewKanaModel()
 
-	name, err := p.Parse(parser.FullName(fullname))
+	err := readDividedNames(stderr, path, parseString, func(_ int, l parser.LastName, f parser.FirstName) error {
+		return m.Add(l, f)
+	})
 	if err != nil {
-		_, err := fmt.Fprintf(stderr, "%s\n", err.Error())
-		if err != nil {
-			return fmt.Errorf("happen error write stderr: %w", err)
-		}
-		return nil
+		return err
 	}
 
-	_, err = fmt.Fprintf(out, "%s\n", name.String())
-	if err != nil {
+	if err := feature.WriteKanaFeatureCSV(out, m); err != nil {
 		return fmt.Errorf("happen error write stdout: %w", err)
 	}
 
 	return nil
 }
 
-func ParseFile(out, stderr io.Writer, path Path, parseString ParseString) error {
-	m := InitKanjiFeatureManager()
-	p := InitNameParser(parseString, m)
+// TrainLatin builds the latin feature table from the divided romanized names in the file, such as "yamada taro".
+// The names which are not in the latin script are reported to stderr and not counted.
+func TrainLatin(out, stderr io.Writer, path Path, parseString ParseString) error {
+	m := feature.NewLatinModel()
+
+	err := readDividedNames(stderr, path, parseString, func(_ int, l parser.LastName, f parser.FirstName) error {
+		return m.Add(l, f)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := feature.WriteLatinFeatureCSV(out, m); err != nil {
+		return fmt.Errorf("happen error write stdout: %w", err)
+	}
+
+	return nil
+}
 
-	r, err := InitReader(path)
+// readDividedNames calls add with the number and the names of each line of the file, in which a last name and
+// a first name are joined by parseString. The invalid lines are reported to stderr and skipped.
+func readDividedNames(stderr io.Writer, path Path, parseString ParseString, add func(int, parser.LastName, parser.FirstName) error) error {
+	f, err := openFile(path)
 	if err != nil {
 		return fmt.Errorf("happen error load file: %w", err)
 	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
 
 	for c := 1; ; c++ {
 		record, err := r.Read()
@@ -137,13 +452,16 @@ func ParseFile(out, stderr io.Writer, path Path, parseString ParseString) error
 			continue
 		}
 
-		name, err := p.Parse(parser.FullName(record[0]))
-		if err != nil {
-			fmt.Fprintf(stderr, "parse error on line %d: %v\n", c, err)
+		names := strings.Split(record[0], string(parseString))
+		if len(names) != 2 {
+			fmt.Fprintf(stderr, "format error on line %d: %v\n", c, record)
 			continue
 		}
 
-		fmt.Fprintf(out, "%s\n", name.String())
+		if err := add(c, parser.LastName(names[0]), parser.FirstName(names[1])); err != nil {
+			fmt.Fprintf(stderr, "train error on line %d: %v\n", c, err)
+			continue
+		}
 	}
 
 	return nil
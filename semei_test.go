@@ -1,12 +1,19 @@
 package seimei_test
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/glassmonkey/seimei/v2"
 	"github.com/glassmonkey/seimei/v2/feature"
+	"github.com/glassmonkey/seimei/v2/parser"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -82,6 +89,147 @@ func TestParseName(t *testing.T) {
 	}
 }
 
+func TestParseCandidates(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name        string
+		inputName   seimei.Name
+		inputParser seimei.ParseString
+		inputTop    seimei.Top
+		inputOpts   []seimei.Option
+		want        string
+		wantErrMsg  string
+	}
+
+	tests := []testdata{
+		{
+			name:        "統計量ベースで候補を並べる",
+			inputName:   "中曽根康弘",
+			inputParser: " ",
+			inputTop:    2,
+			want:        "中曽根 康弘\t0.3127\n中曽 根康弘\t0.2073\n",
+		},
+		{
+			name:        "ルールベースで動作する",
+			inputName:   "乙一",
+			inputParser: "/",
+			inputTop:    3,
+			want:        "乙/一\t1.0000\n",
+		},
+		{
+			name:        "オプションが反映される",
+			inputName:   "中曽根　康弘",
+			inputParser: "/",
+			inputTop:    3,
+			inputOpts:   []seimei.Option{seimei.WithGivenDelimiters()},
+			want:        "中曽根/康弘\t1.0000\n",
+		},
+		{
+			name:        "1文字は分割できない",
+			inputName:   "あ",
+			inputParser: " ",
+			inputTop:    3,
+			want:        "",
+			wantErrMsg:  "parse error: name length needs at least 2 chars\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			if err := seimei.ParseCandidates(stdout, stderr, tt.inputName, tt.inputParser, tt.inputTop, tt.inputOpts...); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			if diff := cmp.Diff(stdout.String(), tt.want); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if diff := cmp.Diff(stderr.String(), tt.wantErrMsg); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestExplainName(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name        string
+		inputName   seimei.Name
+		inputParser seimei.ParseString
+		inputOpts   []seimei.Option
+		want        string
+		wantErrMsg  string
+	}
+
+	// suga is the explanation of 菅義偉 divided by the statistics.
+	suga := `菅/義偉	statistics	0.4803
+split	菅/義偉	probability=0.4803	score=0.6890	order=1.0000	length=0.3779
+	菅	last	order=0.0000	order_mask=-	length=0.1410	length_mask=[1 1 0 0 0 0 0 0]	default=false
+	義	first	order=1.0000	order_mask=[0 0 1 1 0 0]	length=0.9928	length_mask=[0 1 0 0 0 1 0 0]	default=false
+	偉	first	order=0.0000	order_mask=-	length=0.0000	length_mask=[0 0 0 0 1 1 0 0]	default=true
+split	菅義/偉	probability=0.2786	score=0.1444	order=0.0000	length=0.2887
+	菅	last	order=0.0000	order_mask=-	length=0.8590	length_mask=[1 1 0 0 0 0 0 0]	default=false
+	義	last	order=0.0000	order_mask=[0 0 1 1 0 0]	length=0.0072	length_mask=[0 1 0 0 0 1 0 0]	default=false
+	偉	first	order=0.0000	order_mask=-	length=0.0000	length_mask=[0 0 0 0 1 1 0 0]	default=true
+`
+
+	tests := []testdata{
+		{
+			name:        "統計量ベースで動作する",
+			inputName:   "菅義偉",
+			inputParser: "/",
+			want:        suga,
+		},
+		{
+			name:        "ルールベースは説明されない",
+			inputName:   "乙一",
+			inputParser: " ",
+			want:        "乙 一\trule\t1.0000\nthe rule parser gives no explanation\n",
+		},
+		{
+			name:        "オプションが反映される",
+			inputName:   "菅　義偉",
+			inputParser: "/",
+			inputOpts:   []seimei.Option{seimei.WithNormalizers(parser.SpaceNormalizer{})},
+			want:        suga,
+		},
+		{
+			name:        "1文字は分割できない",
+			inputName:   "あ",
+			inputParser: " ",
+			want:        "",
+			wantErrMsg:  "parse error: name length needs at least 2 chars\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			if err := seimei.ExplainName(stdout, stderr, tt.inputName, tt.inputParser, tt.inputOpts...); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			if diff := cmp.Diff(stdout.String(), tt.want); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if diff := cmp.Diff(stderr.String(), tt.wantErrMsg); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
 func TestParseFile(t *testing.T) {
 	t.Parallel()
 
@@ -150,6 +298,99 @@ load line error on line 4: record on line 4: wrong number of fields
 	}
 }
 
+func TestParseFile_Reject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("スコアが閾値未満の行は別の出力に振り分けられる", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		reject := &bytes.Buffer{}
+
+		err := seimei.ParseFile(stdout, stderr, "testdata/low_confidence.csv", " ",
+			seimei.WithMinScore(0.4), seimei.WithRejectWriter(reject))
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if diff := cmp.Diff(stdout.String(), "菅 義偉\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+		if diff := cmp.Diff(reject.String(), "竈門炭治郎,竈門 炭治郎,0.2473,0.0276\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+		if diff := cmp.Diff(stderr.String(), "parse error on line 3: parse error: name length needs at least 2 chars\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+
+	t.Run("出力先がなければエラー出力に書き出す", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+
+		err := seimei.ParseFile(stdout, stderr, "testdata/low_confidence.csv", " ", seimei.WithMinMargin(0.1))
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if diff := cmp.Diff(stdout.String(), "菅 義偉\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+		wantErrOut := `parse error on line 2: parse error: low confidence division: best=竈門 炭治郎, score=0.2473, margin=0.0276
+parse error on line 3: parse error: name length needs at least 2 chars
+`
+		if diff := cmp.Diff(stderr.String(), wantErrOut); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+}
+
+func TestParseReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("1行ずつ結果が書き出される", func(t *testing.T) {
+		t.Parallel()
+
+		inr, inw := io.Pipe()
+		outr, outw := io.Pipe()
+		stderr := &bytes.Buffer{}
+		done := make(chan error)
+
+		go func() {
+			done <- seimei.ParseReader(outw, stderr, inr, " ", seimei.WithOutputFormat(seimei.CSVFormat))
+			outw.Close()
+		}()
+
+		out := bufio.NewReader(outr)
+		for _, tt := range [][2]string{
+			{"田中太郎\n", "input,last_name,first_name,middle_name,score,algorithm,error\n田中太郎,田中,太郎,,0.319858925466683,statistics,\n"},
+			{"乙一\n", "乙一,乙,一,,1,rule,\n"},
+		} {
+			if _, err := io.WriteString(inw, tt[0]); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+
+			got := ""
+			for strings.Count(got, "\n") < strings.Count(tt[1], "\n") {
+				line, err := out.ReadString('\n')
+				if err != nil {
+					t.Fatalf("happen error: %v", err)
+				}
+				got += line
+			}
+			if diff := cmp.Diff(got, tt[1]); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		}
+
+		inw.Close()
+		if err := <-done; err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+	})
+}
+
 func TestParseFile_LargeFile(t *testing.T) {
 	t.Parallel()
 
@@ -180,6 +421,111 @@ func TestParseFile_LargeFile(t *testing.T) {
 	})
 }
 
+func TestParseFile_Cache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("重複した名前は保持した結果を使う", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		if err := seimei.ParseFile(stdout, stderr, "testdata/part_of_error.csv", " ", seimei.WithCacheSize(10)); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		want := &bytes.Buffer{}
+		if err := seimei.ParseFile(want, io.Discard, "testdata/part_of_error.csv", " "); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if diff := cmp.Diff(stdout.String(), want.String()); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+		wantErr := "parse error on line 2: parse error: name length needs at least 2 chars\ncache hits=0 misses=4\n"
+		if diff := cmp.Diff(stderr.String(), wantErr); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+
+	t.Run("巨大なファイル(rows=100000)は一度だけ分割する", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		if err := seimei.ParseFile(stdout, stderr, "testdata/large.csv", " ", seimei.WithCacheSize(1)); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if diff := cmp.Diff(strings.Count(stdout.String(), "竈門 炭治郎\n"), 100000); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+		if diff := cmp.Diff(stderr.String(), "cache hits=99999 misses=1\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+}
+
+func TestParseFile_Workers(t *testing.T) {
+	t.Parallel()
+
+	type testdata struct {
+		name string
+		path seimei.Path
+		opts []seimei.Option
+	}
+	tests := []testdata{
+		{
+			name: "成功",
+			path: "testdata/success.csv",
+		},
+		{
+			name: "一部失敗",
+			path: "testdata/part_of_error.csv",
+		},
+		{
+			name: "フォーマットエラー",
+			path: "testdata/invalid_format.csv",
+		},
+		{
+			name: "巨大なファイル",
+			path: "testdata/large.csv",
+		},
+		{
+			name: "列指定",
+			path: "testdata/multi_column.csv",
+			opts: []seimei.Option{seimei.WithHeader(), seimei.WithColumn("name"), seimei.WithOutputFormat(seimei.CSVFormat)},
+		},
+		{
+			name: "低確信度",
+			path: "testdata/low_confidence.csv",
+			opts: []seimei.Option{seimei.WithMinMargin(0.1), seimei.WithOutputFormat(seimei.JSONFormat)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			wantOut := &bytes.Buffer{}
+			wantErr := &bytes.Buffer{}
+			if err := seimei.ParseFile(wantOut, wantErr, tt.path, " ", tt.opts...); err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			for _, n := range []int{0, 2, 8} {
+				stdout := &bytes.Buffer{}
+				stderr := &bytes.Buffer{}
+				opts := append([]seimei.Option{seimei.WithWorkers(n)}, tt.opts...)
+				if err := seimei.ParseFile(stdout, stderr, tt.path, " ", opts...); err != nil {
+					t.Fatalf("happen error: %v", err)
+				}
+				if diff := cmp.Diff(stdout.String(), wantOut.String()); diff != "" {
+					t.Errorf("failed to test. workers=%d diff: %s", n, diff)
+				}
+				if diff := cmp.Diff(stderr.String(), wantErr.String()); diff != "" {
+					t.Errorf("failed to test. workers=%d diff: %s", n, diff)
+				}
+			}
+		})
+	}
+}
+
 func TestParseFile_NotFoundFile(t *testing.T) {
 	t.Parallel()
 
@@ -259,3 +605,178 @@ func TestInitKanjiFeatureManager(t *testing.T) {
 		})
 	}
 }
+
+func TestTrain(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	if err := seimei.Train(stdout, stderr, "testdata/divided.csv", " "); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := `kanji,oc_family_first,oc_family_other,oc_family_last,oc_given_first,oc_given_other,oc_given_last,lc_family_1,lc_family_2,lc_family_3,lc_family_4,lc_given_1,lc_given_2,lc_given_3,lc_given_4
+々,0,1,0,0,0,0,0,0,1,0,0,0,0,0
+一,0,0,0,1,0,0,0,0,0,0,1,0,0,0
+乙,1,0,0,0,0,0,1,0,0,0,0,0,0,0
+佐,1,0,0,0,0,0,0,0,1,0,0,0,0,0
+小,0,0,0,1,0,0,0,0,0,0,0,0,1,0
+木,0,0,1,0,0,0,0,0,1,0,0,0,0,0
+次,0,0,0,0,1,0,0,0,0,0,0,0,1,0
+治,0,0,0,0,1,0,0,0,0,0,0,0,1,0
+炭,0,0,0,1,0,0,0,0,0,0,0,0,1,0
+竈,1,0,0,0,0,0,0,1,0,0,0,0,0,0
+郎,0,0,0,0,0,2,0,0,0,0,0,0,2,0
+門,0,0,1,0,0,0,0,1,0,0,0,0,0,0
+`
+	if diff := cmp.Diff(stdout.String(), want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+	if diff := cmp.Diff(stderr.String(), "format error on line 4: [田中太郎]\n"); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestTrainKana(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	if err := seimei.TrainKana(stdout, stderr, "testdata/kana_divided.csv", " "); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := `part,prev,next,count
+last,^,か,1
+last,^,や,1
+last,か,ま,1
+last,だ,$,1
+last,ど,$,1
+last,ま,だ,1
+last,ま,ど,1
+last,や,ま,1
+first,^,た,1
+first,^,は,1
+first,う,$,1
+first,こ,$,1
+first,じ,ろ,1
+first,た,ん,1
+first,な,こ,1
+first,は,な,1
+first,ろ,う,1
+first,ん,じ,1
+`
+	if diff := cmp.Diff(stdout.String(), want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+	wantErrOut := `train error on line 3: name must consist of kana: 田中
+format error on line 4: [やまだ]
+`
+	if diff := cmp.Diff(stderr.String(), wantErrOut); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestTrainLatin(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	if err := seimei.TrainLatin(stdout, stderr, "testdata/latin_divided.csv", " "); err != nil {
+		t.Fatalf("happen error: %v", err)
+	}
+
+	want := `part,name,count
+last,ono,1
+last,yamada,1
+first,taro,1
+first,yoko,1
+`
+	if diff := cmp.Diff(stdout.String(), want); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+	wantErrOut := `train error on line 3: name must consist of latin letters: 田中
+format error on line 4: [yamada]
+`
+	if diff := cmp.Diff(stderr.String(), wantErrOut); diff != "" {
+		t.Errorf("failed to test. diff: %s", diff)
+	}
+}
+
+func TestInitKanjiFeatureManager_Shared(t *testing.T) {
+	t.Parallel()
+
+	t.Run("埋め込みの表は一度だけ読み込まれ共有される", func(t *testing.T) {
+		t.Parallel()
+
+		size := 8
+		ms := make([]feature.KanjiFeatureManager, size)
+		var wg sync.WaitGroup
+		for i := 0; i < size; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ms[i] = seimei.InitKanjiFeatureManager()
+			}(i)
+		}
+		wg.Wait()
+
+		want := reflect.ValueOf(ms[0].KanjiFeatureMap).UnsafePointer()
+		for i, m := range ms {
+			if got := reflect.ValueOf(m.KanjiFeatureMap).UnsafePointer(); got != want {
+				t.Errorf("failed to test. manager %d is not shared", i)
+			}
+		}
+		if len(ms[0].KanjiFeatureMap) == 0 {
+			t.Errorf("failed to test. embedded table is empty")
+		}
+	})
+}
+
+func BenchmarkParseName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := seimei.ParseName(io.Discard, io.Discard, "竈門炭治郎", " "); err != nil {
+			b.Fatalf("happen error: %v", err)
+		}
+	}
+}
+
+func TestLoadKanjiFeatureManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("外部の表を読み込める", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open("testdata/kanji.csv")
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		defer f.Close()
+
+		m, err := seimei.LoadKanjiFeatureManager(f)
+		if err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		if err := seimei.ParseName(stdout, stderr, "田中太郎", " ", seimei.WithKanjiFeatureManager(m)); err != nil {
+			t.Fatalf("happen error: %v", err)
+		}
+		if diff := cmp.Diff(stdout.String(), "田 中太郎\n"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+
+	t.Run("不正な表はエラーになる", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := seimei.LoadKanjiFeatureManager(strings.NewReader("kanji,oc_family_first\n"))
+		if !errors.Is(err, feature.ErrInvalidTableHeader) {
+			t.Errorf("error is not expected, got error=(%v), want error=(%v)", err, feature.ErrInvalidTableHeader)
+		}
+	})
+}
@@ -0,0 +1,318 @@
+package seimei
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/glassmonkey/seimei/v2/parser"
+)
+
+const (
+	defaultMaxNameLength = 64
+	defaultMaxBatchSize  = 10000
+	// escapedRuneSize is the bytes of a character escaped in JSON as a surrogate pair, such as "\ud842\udfb7" for 𠮷.
+	escapedRuneSize = 12
+	// requestOverhead is the bytes allowed for a name besides its characters, such as the JSON key and quotes.
+	requestOverhead   = 64
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 30 * time.Second
+	ndjsonContentType = "application/x-ndjson"
+	jsonContentType   = "application/json"
+)
+
+var (
+	ErrEmptyRequestName = errors.New("name is empty")
+	ErrLongRequestName  = errors.New("name is too long")
+	ErrLargeBatch       = errors.New("batch has too many names")
+)
+
+// DivideRequest is the body of POST /divide, and an element of the body of POST /divide/batch.
+type DivideRequest struct {
+	Name string `json:"name"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server divides names sent as JSON over HTTP.
+// Every request is divided with the same NameParser, so the kanji feature table is loaded only once.
+type Server struct {
+	parser        parser.NameParser
+	maxNameLength int
+	maxBatchSize  int
+	ready         atomic.Bool
+	mux           *http.ServeMux
+}
+
+func NewServer(parseString ParseString, opts ...Option) *Server {
+	cfg := newConfig(opts)
+
+	s := &Server{
+		parser:        cfg.nameParser(parseString),
+		maxNameLength: cfg.maxNameLength,
+		maxBatchSize:  cfg.maxBatchSize,
+		mux:           http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/divide", s.handleDivide)
+	s.mux.HandleFunc("/divide/batch", s.handleBatch)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	s.ready.Store(true)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve listens on addr until ctx is done, and then shuts the server down gracefully.
+// Readiness is reported as unavailable while shutting down.
+func Serve(ctx context.Context, stderr io.Writer, addr string, s *Server) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("happen error listen: %w", err)
+	}
+
+	hs := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	fmt.Fprintf(stderr, "listening on %s\n", l.Addr())
+
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- hs.Serve(l)
+	}()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("happen error serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.ready.Store(false)
+
+	sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := hs.Shutdown(sctx); err != nil {
+		return fmt.Errorf("happen error shutdown: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) handleDivide(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req DivideRequest
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.requestSize(1)))
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, decodeStatus(err), fmt.Errorf("invalid request: %w", err))
+
+		return
+	}
+
+	if err := s.validate(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	name, err := s.parser.Parse(parser.FullName(req.Name))
+
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusUnprocessableEntity
+	}
+
+	writeJSON(w, status, NewResult(req.Name, name, err))
+}
+
+// handleBatch divides a JSON array of DivideRequest into an array of Result.
+// NDJSON is divided line by line instead, and each Result is sent as soon as it is divided.
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, s.requestSize(s.maxBatchSize))
+
+	if t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); t == ndjsonContentType {
+		s.streamBatch(r.Context(), w, json.NewDecoder(body))
+
+		return
+	}
+
+	var reqs []DivideRequest
+	if err := json.NewDecoder(body).Decode(&reqs); err != nil {
+		writeError(w, decodeStatus(err), fmt.Errorf("invalid request: %w", err))
+
+		return
+	}
+
+	if len(reqs) > s.maxBatchSize {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("%w: more than %d", ErrLargeBatch, s.maxBatchSize))
+
+		return
+	}
+
+	names := make([]parser.FullName, len(reqs))
+
+	for i, req := range reqs {
+		if err := s.validate(req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("index %d: %w", i, err))
+
+			return
+		}
+
+		names[i] = parser.FullName(req.Name)
+	}
+
+	//nolint:exhaustivestruct
+	divided, err := s.parser.ParseBatch(r.Context(), names, parser.BatchOptions{})
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+
+		return
+	}
+
+	results := make([]Result, len(divided))
+	for i, d := range divided {
+		results[i] = NewResult(string(d.Input), d.Name, d.Err)
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// streamBatch writes a Result per line of dec. An invalid line is answered with its error and stops the stream,
+// since the status is already sent.
+func (s *Server) streamBatch(ctx context.Context, w http.ResponseWriter, dec *json.Decoder) {
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	for c := 1; ctx.Err() == nil; c++ {
+		var req DivideRequest
+
+		err := dec.Decode(&req)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err == nil && c > s.maxBatchSize {
+			err = fmt.Errorf("%w: more than %d", ErrLargeBatch, s.maxBatchSize)
+		}
+
+		if err == nil {
+			err = s.validate(req)
+		}
+
+		if err != nil {
+			_ = enc.Encode(errorResponse{Error: fmt.Sprintf("line %d: %v", c, err)})
+
+			return
+		}
+
+		name, err := s.parser.Parse(parser.FullName(req.Name))
+		if err := enc.Encode(NewResult(req.Name, name, err)); err != nil {
+			return
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *Server) validate(req DivideRequest) error {
+	return validateRequestName(req.Name, s.maxNameLength)
+}
+
+func validateRequestName(name string, maxNameLength int) error {
+	if name == "" {
+		return ErrEmptyRequestName
+	}
+
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("%w: more than %d characters", ErrLongRequestName, maxNameLength)
+	}
+
+	return nil
+}
+
+// requestSize is the largest body accepted for n names, which may have every character escaped.
+func (s *Server) requestSize(n int) int64 {
+	return int64(n) * int64(s.maxNameLength*escapedRuneSize+requestOverhead)
+}
+
+func decodeStatus(err error) int {
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,222 @@
+package seimei_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glassmonkey/seimei/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(seimei.NewServer(" ", seimei.WithMaxNameLength(8), seimei.WithMaxBatchSize(3)))
+	t.Cleanup(ts.Close)
+
+	type testdata struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}
+	tests := []testdata{
+		{
+			name:       "分割",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{"name":"田中太郎"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}` + "\n",
+		},
+		{
+			name:       "分割できない",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{"name":"乙"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"parse error: name length needs at least 2 chars"}` + "\n",
+		},
+		{
+			name:       "名前が空",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"name is empty"}` + "\n",
+		},
+		{
+			name:       "名前が長すぎる",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{"name":"寿限無寿限無五劫の擦り切れ"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"name is too long: more than 8 characters"}` + "\n",
+		},
+		{
+			name:       "本文が大きすぎる",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{"name":"` + strings.Repeat(" ", 1000) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"error":"invalid request: http: request body too large"}` + "\n",
+		},
+		{
+			name:       "エスケープされた名前",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `{"name":"` + strings.Repeat(`\ud842\udfb7`, 8) + `"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"input":"𠮷𠮷𠮷𠮷𠮷𠮷𠮷𠮷","last_name":"𠮷","first_name":"𠮷𠮷𠮷𠮷𠮷𠮷𠮷","score":0.125,"algorithm":"statistics","error":""}` + "\n",
+		},
+		{
+			name:       "JSONでない",
+			method:     http.MethodPost,
+			path:       "/divide",
+			body:       `田中太郎`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid request: invalid character '田' looking for beginning of value"}` + "\n",
+		},
+		{
+			name:       "POST以外",
+			method:     http.MethodGet,
+			path:       "/divide",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"error":"method GET is not allowed"}` + "\n",
+		},
+		{
+			name:       "一括分割",
+			method:     http.MethodPost,
+			path:       "/divide/batch",
+			body:       `[{"name":"田中太郎"},{"name":"乙"},{"name":"竈門炭治郎"}]`,
+			wantStatus: http.StatusOK,
+			wantBody: `[{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""},` +
+				`{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"line 2: parse error: name length needs at least 2 chars"},` +
+				`{"input":"竈門炭治郎","last_name":"竈門","first_name":"炭治郎","score":0.2472726697308935,"algorithm":"statistics","error":""}]` + "\n",
+		},
+		{
+			name:       "一括分割の件数が多すぎる",
+			method:     http.MethodPost,
+			path:       "/divide/batch",
+			body:       `[{"name":"田中太郎"},{"name":"田中太郎"},{"name":"田中太郎"},{"name":"田中太郎"}]`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"error":"batch has too many names: more than 3"}` + "\n",
+		},
+		{
+			name:       "一括分割に空の名前",
+			method:     http.MethodPost,
+			path:       "/divide/batch",
+			body:       `[{"name":"田中太郎"},{"name":""}]`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"index 1: name is empty"}` + "\n",
+		},
+		{
+			name:        "NDJSONの一括分割",
+			method:      http.MethodPost,
+			path:        "/divide/batch",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"田中太郎\"}\n{\"name\":\"乙\"}\n",
+			wantStatus:  http.StatusOK,
+			wantBody: `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}` + "\n" +
+				`{"input":"乙","last_name":"","first_name":"","score":0,"algorithm":"","error":"parse error: name length needs at least 2 chars"}` + "\n",
+		},
+		{
+			name:        "NDJSONの不正な行で止まる",
+			method:      http.MethodPost,
+			path:        "/divide/batch",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"田中太郎\"}\n{\"name\":\"\"}\n{\"name\":\"竈門炭治郎\"}\n",
+			wantStatus:  http.StatusOK,
+			wantBody: `{"input":"田中太郎","last_name":"田中","first_name":"太郎","score":0.319858925466683,"algorithm":"statistics","error":""}` + "\n" +
+				`{"error":"line 2: name is empty"}` + "\n",
+		},
+		{
+			name:       "ヘルスチェック",
+			method:     http.MethodGet,
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"ok"}` + "\n",
+		},
+		{
+			name:       "レディネスチェック",
+			method:     http.MethodGet,
+			path:       "/readyz",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"ready"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			defer res.Body.Close()
+			got, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+			if diff := cmp.Diff(res.StatusCode, tt.wantStatus); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+			if diff := cmp.Diff(string(got), tt.wantBody); diff != "" {
+				t.Errorf("failed to test. diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("コンテキストの終了で停止する", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stderr := &bytes.Buffer{}
+		errc := make(chan error, 1)
+		go func() {
+			errc <- seimei.Serve(ctx, stderr, "127.0.0.1:0", seimei.NewServer(" "))
+		}()
+		cancel()
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Fatalf("happen error: %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("failed to test. server did not stop")
+		}
+		if !strings.HasPrefix(stderr.String(), "listening on 127.0.0.1:") {
+			t.Errorf("failed to test. stderr: %s", stderr.String())
+		}
+	})
+
+	t.Run("待ち受けられないアドレス", func(t *testing.T) {
+		t.Parallel()
+
+		err := seimei.Serve(context.Background(), io.Discard, "127.0.0.1:-1", seimei.NewServer(" "))
+		if diff := cmp.Diff(err.Error(), "happen error listen: listen tcp: address -1: invalid port"); diff != "" {
+			t.Errorf("failed to test. diff: %s", diff)
+		}
+	})
+}